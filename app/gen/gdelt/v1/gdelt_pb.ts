@@ -10,7 +10,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file gdelt/v1/gdelt.proto.
  */
 export const file_gdelt_v1_gdelt: GenFile = /*@__PURE__*/
-  fileDesc("ChRnZGVsdC92MS9nZGVsdC5wcm90bxIIZ2RlbHQudjEicwoVU2VhcmNoQXJ0aWNsZXNSZXF1ZXN0Eg0KBXF1ZXJ5GAEgASgJEhAKCHRpbWVzcGFuGAIgASgJEhIKCnN0YXJ0X2RhdGUYAyABKAkSEAoIZW5kX2RhdGUYBCABKAkSEwoLbWF4X3JlY29yZHMYBSABKAUiWQoHQXJ0aWNsZRILCgN1cmwYASABKAkSDQoFdGl0bGUYAiABKAkSDgoGZG9tYWluGAMgASgJEhAKCGxhbmd1YWdlGAQgASgJEhAKCHNlZW5kYXRlGAUgASgJIj0KFlNlYXJjaEFydGljbGVzUmVzcG9uc2USIwoIYXJ0aWNsZXMYASADKAsyES5nZGVsdC52MS5BcnRpY2xlImkKEkdldFRpbWVsaW5lUmVxdWVzdBINCgVxdWVyeRgBIAEoCRIMCgRtb2RlGAIgASgJEhAKCHRpbWVzcGFuGAMgASgJEhIKCnN0YXJ0X2RhdGUYBCABKAkSEAoIZW5kX2RhdGUYBSABKAkiLAoNVGltZWxpbmVQb2ludBIMCgRkYXRlGAEgASgJEg0KBXZhbHVlGAIgASgBIj4KE0dldFRpbWVsaW5lUmVzcG9uc2USJwoGcG9pbnRzGAEgAygLMhcuZ2RlbHQudjEuVGltZWxpbmVQb2ludDKvAQoMR2RlbHRTZXJ2aWNlElMKDlNlYXJjaEFydGljbGVzEh8uZ2RlbHQudjEuU2VhcmNoQXJ0aWNsZXNSZXF1ZXN0GiAuZ2RlbHQudjEuU2VhcmNoQXJ0aWNsZXNSZXNwb25zZRJKCgtHZXRUaW1lbGluZRIcLmdkZWx0LnYxLkdldFRpbWVsaW5lUmVxdWVzdBodLmdkZWx0LnYxLkdldFRpbWVsaW5lUmVzcG9uc2VCI1ohaW1wbHkvc2VydmVyL2dlbi9nZGVsdC92MTtnZGVsdHYxYgZwcm90bzM");
+  fileDesc("ChRnZGVsdC92MS9nZGVsdC5wcm90bxIIZ2RlbHQudjEiqAEKFVNlYXJjaEFydGljbGVzUmVxdWVzdBINCgVxdWVyeRgBIAEoCRIQCgh0aW1lc3BhbhgCIAEoCRISCgpzdGFydF9kYXRlGAMgASgJEhAKCGVuZF9kYXRlGAQgASgJEhMKC21heF9yZWNvcmRzGAUgASgFEgwKBHNvcnQYBiABKAkSJQoHZXhjbHVkZRgHIAEoCzIULmdkZWx0LnYxLkV4Y2x1c2lvbnMiUwoKRXhjbHVzaW9ucxIPCgdkb21haW5zGAEgAygJEhEKCWNvdW50cmllcxgCIAMoCRIRCglsYW5ndWFnZXMYAyADKAkSDgoGdGhlbWVzGAQgAygJIrEBCgdBcnRpY2xlEgsKA3VybBgBIAEoCRINCgV0aXRsZRgCIAEoCRIOCgZkb21haW4YAyABKAkSEAoIbGFuZ3VhZ2UYBCABKAkSEAoIc2VlbmRhdGUYBSABKAkSEQoJc2Vlbl90aW1lGAYgASgJEhUKDWxhbmd1YWdlX2NvZGUYByABKAkSFgoOc291cmNlX2NvdW50cnkYCCABKAkSFAoMY291bnRyeV9jb2RlGAkgASgJIj0KFlNlYXJjaEFydGljbGVzUmVzcG9uc2USIwoIYXJ0aWNsZXMYASADKAsyES5nZGVsdC52MS5BcnRpY2xlIqkBChJHZXRUaW1lbGluZVJlcXVlc3QSDQoFcXVlcnkYASABKAkSDAoEbW9kZRgCIAEoCRIQCgh0aW1lc3BhbhgDIAEoCRISCgpzdGFydF9kYXRlGAQgASgJEhAKCGVuZF9kYXRlGAUgASgJEhcKD3RpbWVsaW5lX3Ntb290aBgGIAEoBRIlCgdleGNsdWRlGAcgASgLMhQuZ2RlbHQudjEuRXhjbHVzaW9ucyJVCg1UaW1lbGluZVBvaW50EgwKBGRhdGUYASABKAkSDQoFdmFsdWUYAiABKAESJwoMdG9wX2FydGljbGVzGAMgAygLMhEuZ2RlbHQudjEuQXJ0aWNsZSI+ChNHZXRUaW1lbGluZVJlc3BvbnNlEicKBnBvaW50cxgBIAMoCzIXLmdkZWx0LnYxLlRpbWVsaW5lUG9pbnQiVwoVRXhwb3J0VGltZWxpbmVSZXF1ZXN0Ei4KCHRpbWVsaW5lGAEgASgLMhwuZ2RlbHQudjEuR2V0VGltZWxpbmVSZXF1ZXN0Eg4KBmZvcm1hdBgCIAEoCSJOChZFeHBvcnRUaW1lbGluZVJlc3BvbnNlEgwKBGRhdGEYASABKAwSFAoMY29udGVudF90eXBlGAIgASgJEhAKCGZpbGVuYW1lGAMgASgJIiwKDExhYmVsZWRRdWVyeRINCgVsYWJlbBgBIAEoCRINCgVxdWVyeRgCIAEoCSLWAQoXQ29tcGFyZVRpbWVsaW5lc1JlcXVlc3QSJwoHcXVlcmllcxgBIAMoCzIWLmdkZWx0LnYxLkxhYmVsZWRRdWVyeRIMCgRtb2RlGAIgASgJEhAKCHRpbWVzcGFuGAMgASgJEhIKCnN0YXJ0X2RhdGUYBCABKAkSEAoIZW5kX2RhdGUYBSABKAkSFwoPdGltZWxpbmVfc21vb3RoGAYgASgFEiUKB2V4Y2x1ZGUYByABKAsyFC5nZGVsdC52MS5FeGNsdXNpb25zEgwKBGZpbGwYCCABKAkihQEKD0NvbXBhcmlzb25Qb2ludBIMCgRkYXRlGAEgASgJEjUKBnZhbHVlcxgCIAMoCzIlLmdkZWx0LnYxLkNvbXBhcmlzb25Qb2ludC5WYWx1ZXNFbnRyeRotCgtWYWx1ZXNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAE6AjgBIlUKGENvbXBhcmVUaW1lbGluZXNSZXNwb25zZRIOCgZzZXJpZXMYASADKAkSKQoGcG9pbnRzGAIgAygLMhkuZ2RlbHQudjEuQ29tcGFyaXNvblBvaW50IpEBChNHZXRXb3JkQ2xvdWRSZXF1ZXN0Eg0KBXF1ZXJ5GAEgASgJEgwKBG1vZGUYAiABKAkSEAoIdGltZXNwYW4YAyABKAkSEgoKc3RhcnRfZGF0ZRgEIAEoCRIQCghlbmRfZGF0ZRgFIAEoCRIlCgdleGNsdWRlGAYgASgLMhQuZ2RlbHQudjEuRXhjbHVzaW9ucyIqCgpUZXJtV2VpZ2h0EgwKBHRlcm0YASABKAkSDgoGd2VpZ2h0GAIgASgBIjsKFEdldFdvcmRDbG91ZFJlc3BvbnNlEiMKBXRlcm1zGAEgAygLMhQuZ2RlbHQudjEuVGVybVdlaWdodCIzChNTZWFyY2hUaGVtZXNSZXF1ZXN0Eg0KBXF1ZXJ5GAEgASgJEg0KBWxpbWl0GAIgASgFIjoKBVRoZW1lEgwKBG5hbWUYASABKAkSEwoLZGVzY3JpcHRpb24YAiABKAkSDgoGZmFtaWx5GAMgASgIIjcKFFNlYXJjaFRoZW1lc1Jlc3BvbnNlEh8KBnRoZW1lcxgBIAMoCzIPLmdkZWx0LnYxLlRoZW1lIi8KDkZpZWxkVmlvbGF0aW9uEg0KBWZpZWxkGAEgASgJEg4KBnJlYXNvbhgCIAEoCTL9AwoMR2RlbHRTZXJ2aWNlElMKDlNlYXJjaEFydGljbGVzEh8uZ2RlbHQudjEuU2VhcmNoQXJ0aWNsZXNSZXF1ZXN0GiAuZ2RlbHQudjEuU2VhcmNoQXJ0aWNsZXNSZXNwb25zZRJKCgtHZXRUaW1lbGluZRIcLmdkZWx0LnYxLkdldFRpbWVsaW5lUmVxdWVzdBodLmdkZWx0LnYxLkdldFRpbWVsaW5lUmVzcG9uc2USTQoMR2V0V29yZENsb3VkEh0uZ2RlbHQudjEuR2V0V29yZENsb3VkUmVxdWVzdBoeLmdkZWx0LnYxLkdldFdvcmRDbG91ZFJlc3BvbnNlEk0KDFNlYXJjaFRoZW1lcxIdLmdkZWx0LnYxLlNlYXJjaFRoZW1lc1JlcXVlc3QaHi5nZGVsdC52MS5TZWFyY2hUaGVtZXNSZXNwb25zZRJTCg5FeHBvcnRUaW1lbGluZRIfLmdkZWx0LnYxLkV4cG9ydFRpbWVsaW5lUmVxdWVzdBogLmdkZWx0LnYxLkV4cG9ydFRpbWVsaW5lUmVzcG9uc2USWQoQQ29tcGFyZVRpbWVsaW5lcxIhLmdkZWx0LnYxLkNvbXBhcmVUaW1lbGluZXNSZXF1ZXN0GiIuZ2RlbHQudjEuQ29tcGFyZVRpbWVsaW5lc1Jlc3BvbnNlQiNaIWltcGx5L3NlcnZlci9nZW4vZ2RlbHQvdjE7Z2RlbHR2MWIGcHJvdG8z");
 
 /**
  * @generated from message gdelt.v1.SearchArticlesRequest
@@ -40,6 +40,16 @@ export type SearchArticlesRequest = Message<"gdelt.v1.SearchArticlesRequest"> &
    * @generated from field: int32 max_records = 5;
    */
   maxRecords: number;
+
+  /**
+   * @generated from field: string sort = 6;
+   */
+  sort: string;
+
+  /**
+   * @generated from field: gdelt.v1.Exclusions exclude = 7;
+   */
+  exclude?: Exclusions;
 };
 
 /**
@@ -49,6 +59,38 @@ export type SearchArticlesRequest = Message<"gdelt.v1.SearchArticlesRequest"> &
 export const SearchArticlesRequestSchema: GenMessage<SearchArticlesRequest> = /*@__PURE__*/
   messageDesc(file_gdelt_v1_gdelt, 0);
 
+/**
+ * @generated from message gdelt.v1.Exclusions
+ */
+export type Exclusions = Message<"gdelt.v1.Exclusions"> & {
+  /**
+   * @generated from field: repeated string domains = 1;
+   */
+  domains: string[];
+
+  /**
+   * @generated from field: repeated string countries = 2;
+   */
+  countries: string[];
+
+  /**
+   * @generated from field: repeated string languages = 3;
+   */
+  languages: string[];
+
+  /**
+   * @generated from field: repeated string themes = 4;
+   */
+  themes: string[];
+};
+
+/**
+ * Describes the message gdelt.v1.Exclusions.
+ * Use `create(ExclusionsSchema)` to create a new message.
+ */
+export const ExclusionsSchema: GenMessage<Exclusions> = /*@__PURE__*/
+  messageDesc(file_gdelt_v1_gdelt, 1);
+
 /**
  * @generated from message gdelt.v1.Article
  */
@@ -77,6 +119,26 @@ export type Article = Message<"gdelt.v1.Article"> & {
    * @generated from field: string seendate = 5;
    */
   seendate: string;
+
+  /**
+   * @generated from field: string seen_time = 6;
+   */
+  seenTime: string;
+
+  /**
+   * @generated from field: string language_code = 7;
+   */
+  languageCode: string;
+
+  /**
+   * @generated from field: string source_country = 8;
+   */
+  sourceCountry: string;
+
+  /**
+   * @generated from field: string country_code = 9;
+   */
+  countryCode: string;
 };
 
 /**
@@ -84,7 +146,7 @@ export type Article = Message<"gdelt.v1.Article"> & {
  * Use `create(ArticleSchema)` to create a new message.
  */
 export const ArticleSchema: GenMessage<Article> = /*@__PURE__*/
-  messageDesc(file_gdelt_v1_gdelt, 1);
+  messageDesc(file_gdelt_v1_gdelt, 2);
 
 /**
  * @generated from message gdelt.v1.SearchArticlesResponse
@@ -101,7 +163,7 @@ export type SearchArticlesResponse = Message<"gdelt.v1.SearchArticlesResponse">
  * Use `create(SearchArticlesResponseSchema)` to create a new message.
  */
 export const SearchArticlesResponseSchema: GenMessage<SearchArticlesResponse> = /*@__PURE__*/
-  messageDesc(file_gdelt_v1_gdelt, 2);
+  messageDesc(file_gdelt_v1_gdelt, 3);
 
 /**
  * @generated from message gdelt.v1.GetTimelineRequest
@@ -131,6 +193,16 @@ export type GetTimelineRequest = Message<"gdelt.v1.GetTimelineRequest"> & {
    * @generated from field: string end_date = 5;
    */
   endDate: string;
+
+  /**
+   * @generated from field: int32 timeline_smooth = 6;
+   */
+  timelineSmooth: number;
+
+  /**
+   * @generated from field: gdelt.v1.Exclusions exclude = 7;
+   */
+  exclude?: Exclusions;
 };
 
 /**
@@ -138,7 +210,7 @@ export type GetTimelineRequest = Message<"gdelt.v1.GetTimelineRequest"> & {
  * Use `create(GetTimelineRequestSchema)` to create a new message.
  */
 export const GetTimelineRequestSchema: GenMessage<GetTimelineRequest> = /*@__PURE__*/
-  messageDesc(file_gdelt_v1_gdelt, 3);
+  messageDesc(file_gdelt_v1_gdelt, 4);
 
 /**
  * @generated from message gdelt.v1.TimelinePoint
@@ -153,6 +225,11 @@ export type TimelinePoint = Message<"gdelt.v1.TimelinePoint"> & {
    * @generated from field: double value = 2;
    */
   value: number;
+
+  /**
+   * @generated from field: repeated gdelt.v1.Article top_articles = 3;
+   */
+  topArticles: Article[];
 };
 
 /**
@@ -160,7 +237,7 @@ export type TimelinePoint = Message<"gdelt.v1.TimelinePoint"> & {
  * Use `create(TimelinePointSchema)` to create a new message.
  */
 export const TimelinePointSchema: GenMessage<TimelinePoint> = /*@__PURE__*/
-  messageDesc(file_gdelt_v1_gdelt, 4);
+  messageDesc(file_gdelt_v1_gdelt, 5);
 
 /**
  * @generated from message gdelt.v1.GetTimelineResponse
@@ -177,7 +254,343 @@ export type GetTimelineResponse = Message<"gdelt.v1.GetTimelineResponse"> & {
  * Use `create(GetTimelineResponseSchema)` to create a new message.
  */
 export const GetTimelineResponseSchema: GenMessage<GetTimelineResponse> = /*@__PURE__*/
-  messageDesc(file_gdelt_v1_gdelt, 5);
+  messageDesc(file_gdelt_v1_gdelt, 6);
+
+/**
+ * @generated from message gdelt.v1.ExportTimelineRequest
+ */
+export type ExportTimelineRequest = Message<"gdelt.v1.ExportTimelineRequest"> & {
+  /**
+   * @generated from field: gdelt.v1.GetTimelineRequest timeline = 1;
+   */
+  timeline?: GetTimelineRequest;
+
+  /**
+   * @generated from field: string format = 2;
+   */
+  format: string;
+};
+
+/**
+ * Describes the message gdelt.v1.ExportTimelineRequest.
+ * Use `create(ExportTimelineRequestSchema)` to create a new message.
+ */
+export const ExportTimelineRequestSchema: GenMessage<ExportTimelineRequest> = /*@__PURE__*/
+  messageDesc(file_gdelt_v1_gdelt, 7);
+
+/**
+ * @generated from message gdelt.v1.ExportTimelineResponse
+ */
+export type ExportTimelineResponse = Message<"gdelt.v1.ExportTimelineResponse"> & {
+  /**
+   * @generated from field: bytes data = 1;
+   */
+  data: Uint8Array;
+
+  /**
+   * @generated from field: string content_type = 2;
+   */
+  contentType: string;
+
+  /**
+   * @generated from field: string filename = 3;
+   */
+  filename: string;
+};
+
+/**
+ * Describes the message gdelt.v1.ExportTimelineResponse.
+ * Use `create(ExportTimelineResponseSchema)` to create a new message.
+ */
+export const ExportTimelineResponseSchema: GenMessage<ExportTimelineResponse> = /*@__PURE__*/
+  messageDesc(file_gdelt_v1_gdelt, 8);
+
+/**
+ * @generated from message gdelt.v1.LabeledQuery
+ */
+export type LabeledQuery = Message<"gdelt.v1.LabeledQuery"> & {
+  /**
+   * @generated from field: string label = 1;
+   */
+  label: string;
+
+  /**
+   * @generated from field: string query = 2;
+   */
+  query: string;
+};
+
+/**
+ * Describes the message gdelt.v1.LabeledQuery.
+ * Use `create(LabeledQuerySchema)` to create a new message.
+ */
+export const LabeledQuerySchema: GenMessage<LabeledQuery> = /*@__PURE__*/
+  messageDesc(file_gdelt_v1_gdelt, 9);
+
+/**
+ * @generated from message gdelt.v1.CompareTimelinesRequest
+ */
+export type CompareTimelinesRequest = Message<"gdelt.v1.CompareTimelinesRequest"> & {
+  /**
+   * @generated from field: repeated gdelt.v1.LabeledQuery queries = 1;
+   */
+  queries: LabeledQuery[];
+
+  /**
+   * @generated from field: string mode = 2;
+   */
+  mode: string;
+
+  /**
+   * @generated from field: string timespan = 3;
+   */
+  timespan: string;
+
+  /**
+   * @generated from field: string start_date = 4;
+   */
+  startDate: string;
+
+  /**
+   * @generated from field: string end_date = 5;
+   */
+  endDate: string;
+
+  /**
+   * @generated from field: int32 timeline_smooth = 6;
+   */
+  timelineSmooth: number;
+
+  /**
+   * @generated from field: gdelt.v1.Exclusions exclude = 7;
+   */
+  exclude?: Exclusions;
+
+  /**
+   * @generated from field: string fill = 8;
+   */
+  fill: string;
+};
+
+/**
+ * Describes the message gdelt.v1.CompareTimelinesRequest.
+ * Use `create(CompareTimelinesRequestSchema)` to create a new message.
+ */
+export const CompareTimelinesRequestSchema: GenMessage<CompareTimelinesRequest> = /*@__PURE__*/
+  messageDesc(file_gdelt_v1_gdelt, 10);
+
+/**
+ * @generated from message gdelt.v1.ComparisonPoint
+ */
+export type ComparisonPoint = Message<"gdelt.v1.ComparisonPoint"> & {
+  /**
+   * @generated from field: string date = 1;
+   */
+  date: string;
+
+  /**
+   * @generated from field: map<string, double> values = 2;
+   */
+  values: { [key: string]: number };
+};
+
+/**
+ * Describes the message gdelt.v1.ComparisonPoint.
+ * Use `create(ComparisonPointSchema)` to create a new message.
+ */
+export const ComparisonPointSchema: GenMessage<ComparisonPoint> = /*@__PURE__*/
+  messageDesc(file_gdelt_v1_gdelt, 11);
+
+/**
+ * @generated from message gdelt.v1.CompareTimelinesResponse
+ */
+export type CompareTimelinesResponse = Message<"gdelt.v1.CompareTimelinesResponse"> & {
+  /**
+   * @generated from field: repeated string series = 1;
+   */
+  series: string[];
+
+  /**
+   * @generated from field: repeated gdelt.v1.ComparisonPoint points = 2;
+   */
+  points: ComparisonPoint[];
+};
+
+/**
+ * Describes the message gdelt.v1.CompareTimelinesResponse.
+ * Use `create(CompareTimelinesResponseSchema)` to create a new message.
+ */
+export const CompareTimelinesResponseSchema: GenMessage<CompareTimelinesResponse> = /*@__PURE__*/
+  messageDesc(file_gdelt_v1_gdelt, 12);
+
+/**
+ * @generated from message gdelt.v1.GetWordCloudRequest
+ */
+export type GetWordCloudRequest = Message<"gdelt.v1.GetWordCloudRequest"> & {
+  /**
+   * @generated from field: string query = 1;
+   */
+  query: string;
+
+  /**
+   * @generated from field: string mode = 2;
+   */
+  mode: string;
+
+  /**
+   * @generated from field: string timespan = 3;
+   */
+  timespan: string;
+
+  /**
+   * @generated from field: string start_date = 4;
+   */
+  startDate: string;
+
+  /**
+   * @generated from field: string end_date = 5;
+   */
+  endDate: string;
+
+  /**
+   * @generated from field: gdelt.v1.Exclusions exclude = 6;
+   */
+  exclude?: Exclusions;
+};
+
+/**
+ * Describes the message gdelt.v1.GetWordCloudRequest.
+ * Use `create(GetWordCloudRequestSchema)` to create a new message.
+ */
+export const GetWordCloudRequestSchema: GenMessage<GetWordCloudRequest> = /*@__PURE__*/
+  messageDesc(file_gdelt_v1_gdelt, 13);
+
+/**
+ * @generated from message gdelt.v1.TermWeight
+ */
+export type TermWeight = Message<"gdelt.v1.TermWeight"> & {
+  /**
+   * @generated from field: string term = 1;
+   */
+  term: string;
+
+  /**
+   * @generated from field: double weight = 2;
+   */
+  weight: number;
+};
+
+/**
+ * Describes the message gdelt.v1.TermWeight.
+ * Use `create(TermWeightSchema)` to create a new message.
+ */
+export const TermWeightSchema: GenMessage<TermWeight> = /*@__PURE__*/
+  messageDesc(file_gdelt_v1_gdelt, 14);
+
+/**
+ * @generated from message gdelt.v1.GetWordCloudResponse
+ */
+export type GetWordCloudResponse = Message<"gdelt.v1.GetWordCloudResponse"> & {
+  /**
+   * @generated from field: repeated gdelt.v1.TermWeight terms = 1;
+   */
+  terms: TermWeight[];
+};
+
+/**
+ * Describes the message gdelt.v1.GetWordCloudResponse.
+ * Use `create(GetWordCloudResponseSchema)` to create a new message.
+ */
+export const GetWordCloudResponseSchema: GenMessage<GetWordCloudResponse> = /*@__PURE__*/
+  messageDesc(file_gdelt_v1_gdelt, 15);
+
+/**
+ * @generated from message gdelt.v1.SearchThemesRequest
+ */
+export type SearchThemesRequest = Message<"gdelt.v1.SearchThemesRequest"> & {
+  /**
+   * @generated from field: string query = 1;
+   */
+  query: string;
+
+  /**
+   * @generated from field: int32 limit = 2;
+   */
+  limit: number;
+};
+
+/**
+ * Describes the message gdelt.v1.SearchThemesRequest.
+ * Use `create(SearchThemesRequestSchema)` to create a new message.
+ */
+export const SearchThemesRequestSchema: GenMessage<SearchThemesRequest> = /*@__PURE__*/
+  messageDesc(file_gdelt_v1_gdelt, 16);
+
+/**
+ * @generated from message gdelt.v1.Theme
+ */
+export type Theme = Message<"gdelt.v1.Theme"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string description = 2;
+   */
+  description: string;
+
+  /**
+   * @generated from field: bool family = 3;
+   */
+  family: boolean;
+};
+
+/**
+ * Describes the message gdelt.v1.Theme.
+ * Use `create(ThemeSchema)` to create a new message.
+ */
+export const ThemeSchema: GenMessage<Theme> = /*@__PURE__*/
+  messageDesc(file_gdelt_v1_gdelt, 17);
+
+/**
+ * @generated from message gdelt.v1.SearchThemesResponse
+ */
+export type SearchThemesResponse = Message<"gdelt.v1.SearchThemesResponse"> & {
+  /**
+   * @generated from field: repeated gdelt.v1.Theme themes = 1;
+   */
+  themes: Theme[];
+};
+
+/**
+ * Describes the message gdelt.v1.SearchThemesResponse.
+ * Use `create(SearchThemesResponseSchema)` to create a new message.
+ */
+export const SearchThemesResponseSchema: GenMessage<SearchThemesResponse> = /*@__PURE__*/
+  messageDesc(file_gdelt_v1_gdelt, 18);
+
+/**
+ * @generated from message gdelt.v1.FieldViolation
+ */
+export type FieldViolation = Message<"gdelt.v1.FieldViolation"> & {
+  /**
+   * @generated from field: string field = 1;
+   */
+  field: string;
+
+  /**
+   * @generated from field: string reason = 2;
+   */
+  reason: string;
+};
+
+/**
+ * Describes the message gdelt.v1.FieldViolation.
+ * Use `create(FieldViolationSchema)` to create a new message.
+ */
+export const FieldViolationSchema: GenMessage<FieldViolation> = /*@__PURE__*/
+  messageDesc(file_gdelt_v1_gdelt, 19);
 
 /**
  * @generated from service gdelt.v1.GdeltService
@@ -199,6 +612,38 @@ export const GdeltService: GenService<{
     input: typeof GetTimelineRequestSchema;
     output: typeof GetTimelineResponseSchema;
   },
+  /**
+   * @generated from rpc gdelt.v1.GdeltService.GetWordCloud
+   */
+  getWordCloud: {
+    methodKind: "unary";
+    input: typeof GetWordCloudRequestSchema;
+    output: typeof GetWordCloudResponseSchema;
+  },
+  /**
+   * @generated from rpc gdelt.v1.GdeltService.SearchThemes
+   */
+  searchThemes: {
+    methodKind: "unary";
+    input: typeof SearchThemesRequestSchema;
+    output: typeof SearchThemesResponseSchema;
+  },
+  /**
+   * @generated from rpc gdelt.v1.GdeltService.ExportTimeline
+   */
+  exportTimeline: {
+    methodKind: "unary";
+    input: typeof ExportTimelineRequestSchema;
+    output: typeof ExportTimelineResponseSchema;
+  },
+  /**
+   * @generated from rpc gdelt.v1.GdeltService.CompareTimelines
+   */
+  compareTimelines: {
+    methodKind: "unary";
+    input: typeof CompareTimelinesRequestSchema;
+    output: typeof CompareTimelinesResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_gdelt_v1_gdelt, 0);
 
@@ -71,10 +71,18 @@ func main() {
 		logger.Fatalf("Failed to listen: %v", err)
 	}
 
+	// Base context for all requests, cancelled if in-flight requests
+	// (and their upstream GDELT calls) outlive the shutdown grace period
+	baseCtx, cancelBase := context.WithCancel(context.Background())
+	defer cancelBase()
+
 	srv := &http.Server{
 		Handler:      handler,
 		ReadTimeout:  5 * time.Second,
 		WriteTimeout: 10 * time.Second,
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
 	}
 
 	// Graceful shutdown
@@ -85,7 +93,10 @@ func main() {
 		logger.Println("Shutting down server...")
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
-		srv.Shutdown(ctx)
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("Shutdown grace period expired, cancelling in-flight requests: %v", err)
+			cancelBase()
+		}
 	}()
 
 	if err := srv.Serve(ln); err != nil && err != http.ErrServerClosed {
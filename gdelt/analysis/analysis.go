@@ -0,0 +1,257 @@
+// Package analysis provides statistics over GDELT timelines: resampling
+// the 15-minute buckets to coarser intervals, rolling statistics, z-scores,
+// percent change and spike detection.
+//
+// Series are handled as []float64 aligned with TimelineResult.Rows, with
+// NaN marking a value missing from a row:
+//
+//	hourly, err := analysis.Resample(timeline, time.Hour, analysis.Mean)
+//	values := analysis.Series(hourly, hourly.SeriesNames[0])
+//	mean := analysis.RollingMean(values, 24)
+//	spikes := analysis.DetectSpikes(hourly, hourly.SeriesNames[0], analysis.DefaultSpikeConfig())
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/tri2820/gdelt"
+)
+
+// Aggregation combines the values that fall into one resampled bucket
+type Aggregation int
+
+const (
+	// Mean averages the values, for normalised series such as volume
+	// intensity or tone
+	Mean Aggregation = iota
+	// Sum adds the values up, for counts such as timelinevolraw
+	Sum
+)
+
+// Resample groups rows into buckets of interval, aligned to UTC (so
+// 24*time.Hour gives calendar days), and combines each series with agg.
+// Buckets are stamped with their start time and only exist where there is
+// data. AllArticles counts are summed and TopArticles concatenated.
+func Resample(r *gdelt.TimelineResult, interval time.Duration, agg Aggregation) (*gdelt.TimelineResult, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("resample interval must be positive, got %v", interval)
+	}
+	if agg != Mean && agg != Sum {
+		return nil, fmt.Errorf("unknown aggregation %d", agg)
+	}
+
+	type bucket struct {
+		row    gdelt.TimelineRow
+		counts map[string]int
+	}
+	buckets := make(map[time.Time]*bucket)
+	var starts []time.Time
+
+	for _, row := range r.Rows {
+		start := row.DateTime.UTC().Truncate(interval)
+		b, ok := buckets[start]
+		if !ok {
+			b = &bucket{
+				row:    gdelt.TimelineRow{DateTime: start, Series: make(map[string]float64)},
+				counts: make(map[string]int),
+			}
+			buckets[start] = b
+			starts = append(starts, start)
+		}
+
+		for name, v := range row.Series {
+			b.row.Series[name] += v
+			b.counts[name]++
+		}
+		if row.AllArticles != nil {
+			total := *row.AllArticles
+			if b.row.AllArticles != nil {
+				total += *b.row.AllArticles
+			}
+			b.row.AllArticles = &total
+		}
+		b.row.TopArticles = append(b.row.TopArticles, row.TopArticles...)
+	}
+
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	rows := make([]gdelt.TimelineRow, len(starts))
+	for i, start := range starts {
+		b := buckets[start]
+		if agg == Mean {
+			for name, n := range b.counts {
+				b.row.Series[name] /= float64(n)
+			}
+		}
+		rows[i] = b.row
+	}
+
+	return &gdelt.TimelineResult{
+		QueryDetails: r.QueryDetails,
+		Rows:         rows,
+		SeriesNames:  r.SeriesNames,
+		Warnings:     r.Warnings,
+	}, nil
+}
+
+// Series returns the values of the named series, one per row, with NaN
+// where a row has no value
+func Series(r *gdelt.TimelineResult, name string) []float64 {
+	values := make([]float64, len(r.Rows))
+	for i, row := range r.Rows {
+		v, ok := row.Series[name]
+		if !ok {
+			v = math.NaN()
+		}
+		values[i] = v
+	}
+	return values
+}
+
+// RollingMean returns the mean of each trailing window of window values,
+// ending at and including each index. NaN values are ignored; the result is
+// NaN until the first window is complete or when a window holds no values.
+func RollingMean(values []float64, window int) []float64 {
+	return rolling(values, window, func(w []float64) float64 {
+		mean, _ := stats(w)
+		return mean
+	})
+}
+
+// RollingStdDev returns the sample standard deviation (n-1 denominator, as
+// pandas computes it) of each trailing window, like RollingMean. A window
+// needs at least two values.
+func RollingStdDev(values []float64, window int) []float64 {
+	return rolling(values, window, func(w []float64) float64 {
+		_, std := stats(w)
+		return std
+	})
+}
+
+// ZScores scores each value against the window values before it: the
+// distance from their mean in standard deviations. The baseline excludes
+// the value itself so a spike doesn't dampen its own score. A flat baseline
+// scores any change as ±Inf; too short a baseline gives NaN.
+func ZScores(values []float64, window int) []float64 {
+	scores := make([]float64, len(values))
+	for i, v := range values {
+		scores[i] = math.NaN()
+		if window < 2 || i < window || math.IsNaN(v) {
+			continue
+		}
+		mean, std := stats(values[i-window : i])
+		switch {
+		case math.IsNaN(std):
+		case std == 0 && v == mean:
+			scores[i] = 0
+		case std == 0:
+			scores[i] = math.Inf(int(math.Copysign(1, v-mean)))
+		default:
+			scores[i] = (v - mean) / std
+		}
+	}
+	return scores
+}
+
+// PercentChange returns the change from the previous value in percent. It
+// is NaN for the first value and where either value is missing or the
+// previous value is zero.
+func PercentChange(values []float64) []float64 {
+	changes := make([]float64, len(values))
+	for i := range values {
+		changes[i] = math.NaN()
+		if i == 0 || values[i-1] == 0 {
+			continue
+		}
+		// NaN inputs propagate
+		changes[i] = (values[i] - values[i-1]) / values[i-1] * 100
+	}
+	return changes
+}
+
+// SpikeConfig controls DetectSpikes
+type SpikeConfig struct {
+	// Window is the number of preceding rows forming the baseline
+	Window int
+	// Threshold is the z-score at or above which a row is a spike
+	Threshold float64
+}
+
+// DefaultSpikeConfig compares each row with the 24 before it (a day of
+// hourly data) and flags values three standard deviations above them
+func DefaultSpikeConfig() SpikeConfig {
+	return SpikeConfig{
+		Window:    24,
+		Threshold: 3,
+	}
+}
+
+// Spike is a row whose value stands out from the rows before it
+type Spike struct {
+	Index    int // Index into TimelineResult.Rows
+	DateTime time.Time
+	Value    float64
+	ZScore   float64
+}
+
+// DetectSpikes returns the rows of the named series whose z-score against
+// the preceding cfg.Window rows is at least cfg.Threshold, in row order.
+// Only rises are flagged; drops never count as spikes.
+func DetectSpikes(r *gdelt.TimelineResult, series string, cfg SpikeConfig) []Spike {
+	values := Series(r, series)
+	var spikes []Spike
+	for i, z := range ZScores(values, cfg.Window) {
+		if z >= cfg.Threshold {
+			spikes = append(spikes, Spike{
+				Index:    i,
+				DateTime: r.Rows[i].DateTime,
+				Value:    values[i],
+				ZScore:   z,
+			})
+		}
+	}
+	return spikes
+}
+
+// rolling applies f to each complete trailing window of values
+func rolling(values []float64, window int, f func([]float64) float64) []float64 {
+	out := make([]float64, len(values))
+	for i := range values {
+		if window < 1 || i < window-1 {
+			out[i] = math.NaN()
+			continue
+		}
+		out[i] = f(values[i-window+1 : i+1])
+	}
+	return out
+}
+
+// stats returns the mean and sample standard deviation of the non-NaN
+// values in w, each NaN when there are too few values
+func stats(w []float64) (mean, std float64) {
+	n, sum := 0, 0.0
+	for _, v := range w {
+		if !math.IsNaN(v) {
+			n++
+			sum += v
+		}
+	}
+	if n == 0 {
+		return math.NaN(), math.NaN()
+	}
+	mean = sum / float64(n)
+	if n < 2 {
+		return mean, math.NaN()
+	}
+
+	ss := 0.0
+	for _, v := range w {
+		if !math.IsNaN(v) {
+			ss += (v - mean) * (v - mean)
+		}
+	}
+	return mean, math.Sqrt(ss / float64(n-1))
+}
@@ -0,0 +1,179 @@
+package analysis
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tri2820/gdelt"
+)
+
+var nan = math.NaN()
+
+// base is the start of every test timeline
+var base = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// timeline builds a 15-minute timeline of a single series "v" from values,
+// leaving out NaN entries
+func timeline(values ...float64) *gdelt.TimelineResult {
+	r := &gdelt.TimelineResult{SeriesNames: []string{"v"}}
+	for i, v := range values {
+		row := gdelt.TimelineRow{DateTime: base.Add(time.Duration(i) * gdelt.UpdateInterval), Series: map[string]float64{}}
+		if !math.IsNaN(v) {
+			row.Series["v"] = v
+		}
+		r.Rows = append(r.Rows, row)
+	}
+	return r
+}
+
+// floatsEqual compares to 1e-9, treating NaNs as equal
+func floatsEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		switch {
+		case math.IsNaN(a[i]) || math.IsNaN(b[i]):
+			if math.IsNaN(a[i]) != math.IsNaN(b[i]) {
+				return false
+			}
+		case math.IsInf(a[i], 0) || math.IsInf(b[i], 0):
+			if a[i] != b[i] {
+				return false
+			}
+		case math.Abs(a[i]-b[i]) > 1e-9:
+			return false
+		}
+	}
+	return true
+}
+
+func TestResample(t *testing.T) {
+	// Two hours of 15-minute data with a gap, then a row on the next day
+	r := timeline(1, 2, 3, nan, 10, 20, 30, 40)
+	count := func(n int) *int { return &n }
+	r.Rows[0].AllArticles = count(100)
+	r.Rows[1].AllArticles = count(200)
+	r.Rows = append(r.Rows, gdelt.TimelineRow{DateTime: base.Add(25 * time.Hour), Series: map[string]float64{"v": 5}})
+
+	tests := []struct {
+		name     string
+		interval time.Duration
+		agg      Aggregation
+		times    []time.Time
+		values   []float64
+	}{
+		{"hourly mean", time.Hour, Mean, []time.Time{base, base.Add(time.Hour), base.Add(25 * time.Hour)}, []float64{2, 25, 5}},
+		{"hourly sum", time.Hour, Sum, []time.Time{base, base.Add(time.Hour), base.Add(25 * time.Hour)}, []float64{6, 100, 5}},
+		{"daily sum", 24 * time.Hour, Sum, []time.Time{base, base.Add(24 * time.Hour)}, []float64{106, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resample(r, tt.interval, tt.agg)
+			if err != nil {
+				t.Fatalf("Resample() error = %v", err)
+			}
+			var times []time.Time
+			for _, row := range got.Rows {
+				times = append(times, row.DateTime)
+			}
+			if !reflect.DeepEqual(times, tt.times) {
+				t.Errorf("times = %v, want %v", times, tt.times)
+			}
+			if values := Series(got, "v"); !floatsEqual(values, tt.values) {
+				t.Errorf("values = %v, want %v", values, tt.values)
+			}
+			if all := got.Rows[0].AllArticles; all == nil || *all != 300 {
+				t.Errorf("AllArticles = %v, want 300", all)
+			}
+			if got.Rows[1].AllArticles != nil {
+				t.Errorf("AllArticles of a bucket without counts = %d, want nil", *got.Rows[1].AllArticles)
+			}
+		})
+	}
+
+	if _, err := Resample(r, 0, Mean); err == nil {
+		t.Error("Resample() should reject a zero interval")
+	}
+}
+
+func TestRollingStatistics(t *testing.T) {
+	values := []float64{1, 2, 3, nan, 5, 6}
+
+	wantMean := []float64{nan, nan, 2, 2.5, 4, 5.5}
+	if got := RollingMean(values, 3); !floatsEqual(got, wantMean) {
+		t.Errorf("RollingMean() = %v, want %v", got, wantMean)
+	}
+
+	// Sample standard deviations: {1,2,3} -> 1, {2,3} -> sqrt(0.5),
+	// {3,5} -> sqrt(2), {5,6} -> sqrt(0.5)
+	wantStd := []float64{nan, nan, 1, math.Sqrt(0.5), math.Sqrt(2), math.Sqrt(0.5)}
+	if got := RollingStdDev(values, 3); !floatsEqual(got, wantStd) {
+		t.Errorf("RollingStdDev() = %v, want %v", got, wantStd)
+	}
+
+	if got := RollingStdDev([]float64{4, nan, nan}, 2); !floatsEqual(got, []float64{nan, nan, nan}) {
+		t.Errorf("RollingStdDev() of single values = %v, want NaN", got)
+	}
+}
+
+func TestZScores(t *testing.T) {
+	// Each score uses the three values before it, e.g. index 3 has the
+	// baseline {2, 4, 6}: mean 4, sample std 2
+	values := []float64{2, 4, 6, 10, 5, 5, 5, 5, 7}
+	got := ZScores(values, 3)
+	want := []float64{
+		nan, nan, nan,
+		3,
+		(5 - 20.0/3) / math.Sqrt(28.0/3), // {4, 6, 10}
+		(5 - 7) / math.Sqrt(7),           // {6, 10, 5}
+		(5 - 20.0/3) / math.Sqrt(25.0/3), // {10, 5, 5}
+		0,                                // flat baseline, no change
+		math.Inf(1),                      // flat baseline, then a rise
+	}
+	if !floatsEqual(got, want) {
+		t.Errorf("ZScores() = %v, want %v", got, want)
+	}
+
+	if got := ZScores([]float64{1, nan, 3}, 2); !floatsEqual(got, []float64{nan, nan, nan}) {
+		t.Errorf("ZScores() with a short baseline = %v, want NaN", got)
+	}
+}
+
+func TestPercentChange(t *testing.T) {
+	got := PercentChange([]float64{10, 15, 0, 5, nan, 4})
+	want := []float64{nan, 50, -100, nan, nan, nan}
+	if !floatsEqual(got, want) {
+		t.Errorf("PercentChange() = %v, want %v", got, want)
+	}
+}
+
+func TestDetectSpikes(t *testing.T) {
+	r := timeline(10, 11, 9, 10, 11, 9, 10, 40, 11, 9, 3)
+	spikes := DetectSpikes(r, "v", SpikeConfig{Window: 6, Threshold: 3})
+	if len(spikes) != 1 {
+		t.Fatalf("DetectSpikes() = %+v, want one spike", spikes)
+	}
+	s := spikes[0]
+	if s.Index != 7 || s.Value != 40 || !s.DateTime.Equal(base.Add(7*gdelt.UpdateInterval)) {
+		t.Errorf("spike = %+v, want row 7", s)
+	}
+	// Baseline {10, 11, 9, 10, 11, 9}: mean 10, sample std sqrt(0.8)
+	if want := 30 / math.Sqrt(0.8); math.Abs(s.ZScore-want) > 1e-9 {
+		t.Errorf("ZScore = %v, want %v", s.ZScore, want)
+	}
+
+	// A lower threshold also catches smaller rises, but never the drop
+	for _, s := range DetectSpikes(r, "v", SpikeConfig{Window: 3, Threshold: 1}) {
+		if s.Value < 10 {
+			t.Errorf("DetectSpikes() flagged a drop: %+v", s)
+		}
+	}
+
+	if spikes := DetectSpikes(r, "missing", DefaultSpikeConfig()); len(spikes) != 0 {
+		t.Errorf("DetectSpikes() on a missing series = %+v", spikes)
+	}
+}
@@ -0,0 +1,348 @@
+package gdelt
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// A minimal writer for the Arrow IPC streaming format, enough to export a
+// timeline without depending on the Arrow libraries. It writes a schema
+// message, one record batch and the end-of-stream marker; see
+// https://arrow.apache.org/docs/format/Columnar.html#ipc-streaming-format
+
+// arrowType is a column type supported by the writer
+type arrowType int
+
+const (
+	arrowTimestamp arrowType = iota // timestamp[s, tz=UTC], stored in values
+	arrowInt64                      // stored in values
+	arrowFloat64                    // stored in floats
+)
+
+// arrowColumn is one column of a record batch. valid is only consulted for
+// nullable columns.
+type arrowColumn struct {
+	name     string
+	typ      arrowType
+	nullable bool
+	valid    []bool
+	values   []int64
+	floats   []float64
+}
+
+// Flatbuffer enum and union values from Schema.fbs and Message.fbs
+const (
+	arrowMetadataV5       = 4
+	arrowHeaderSchema     = 1
+	arrowHeaderRecord     = 3
+	arrowTypeInt          = 2
+	arrowTypeFloatingPt   = 3
+	arrowTypeTimestamp    = 10
+	arrowPrecisionDouble  = 2
+	arrowTimeUnitSecond   = 0
+	arrowContinuationMark = 0xFFFFFFFF
+)
+
+// writeArrowStream writes columns, each holding length values, as an Arrow
+// IPC stream
+func writeArrowStream(w io.Writer, length int, columns []arrowColumn) error {
+	if err := writeArrowMessage(w, arrowSchema(columns), nil); err != nil {
+		return err
+	}
+	meta, body := arrowRecordBatch(length, columns)
+	if err := writeArrowMessage(w, meta, body); err != nil {
+		return err
+	}
+	// End-of-stream marker: a continuation followed by a zero length
+	_, err := w.Write([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0})
+	return err
+}
+
+// writeArrowMessage frames an encapsulated message: the continuation
+// marker, the metadata length, the flatbuffer metadata and the body. The
+// builder pads metadata to 8 bytes and bodies are padded as they are built.
+func writeArrowMessage(w io.Writer, meta, body []byte) error {
+	var prefix [8]byte
+	binary.LittleEndian.PutUint32(prefix[:4], arrowContinuationMark)
+	binary.LittleEndian.PutUint32(prefix[4:], uint32(len(meta)))
+	for _, b := range [][]byte{prefix[:], meta, body} {
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// arrowSchema builds the metadata of the schema message
+func arrowSchema(columns []arrowColumn) []byte {
+	b := &fbBuilder{}
+
+	fields := make([]int, len(columns))
+	for i, col := range columns {
+		name := b.createString(col.name)
+		children := b.createOffsetVector(nil)
+
+		var typeType byte
+		var typ int
+		switch col.typ {
+		case arrowTimestamp:
+			tz := b.createString("UTC")
+			b.startTable(2)
+			b.addInt16(0, arrowTimeUnitSecond)
+			b.addOffset(1, tz)
+			typeType, typ = arrowTypeTimestamp, b.endTable()
+		case arrowInt64:
+			b.startTable(2)
+			b.addInt32(0, 64)
+			b.addBool(1, true)
+			typeType, typ = arrowTypeInt, b.endTable()
+		case arrowFloat64:
+			b.startTable(1)
+			b.addInt16(0, arrowPrecisionDouble)
+			typeType, typ = arrowTypeFloatingPt, b.endTable()
+		}
+
+		b.startTable(7)
+		b.addOffset(0, name)
+		b.addBool(1, col.nullable)
+		b.addUint8(2, typeType)
+		b.addOffset(3, typ)
+		b.addOffset(5, children)
+		fields[i] = b.endTable()
+	}
+	fieldVec := b.createOffsetVector(fields)
+
+	b.startTable(4)
+	b.addInt16(0, 0) // little endian
+	b.addOffset(1, fieldVec)
+	schema := b.endTable()
+
+	return arrowMessage(b, arrowHeaderSchema, schema, 0)
+}
+
+// arrowRecordBatch builds the metadata and body of the record batch message
+func arrowRecordBatch(length int, columns []arrowColumn) (meta, body []byte) {
+	type buffer struct{ offset, length int64 }
+	type node struct{ length, nullCount int64 }
+	var buffers []buffer
+	var nodes []node
+
+	// Each buffer starts on an 8-byte boundary of the body
+	appendBuffer := func(data []byte) {
+		buffers = append(buffers, buffer{int64(len(body)), int64(len(data))})
+		body = append(body, data...)
+		for len(body)%8 != 0 {
+			body = append(body, 0)
+		}
+	}
+
+	for _, col := range columns {
+		nulls := 0
+		var bitmap []byte
+		if col.nullable {
+			bitmap = make([]byte, (length+7)/8)
+			for i, ok := range col.valid {
+				if ok {
+					bitmap[i/8] |= 1 << (i % 8)
+				} else {
+					nulls++
+				}
+			}
+		}
+		nodes = append(nodes, node{int64(length), int64(nulls)})
+
+		// The validity bitmap may be omitted when nothing is null
+		if nulls == 0 {
+			bitmap = nil
+		}
+		appendBuffer(bitmap)
+
+		data := make([]byte, 8*length)
+		for i := 0; i < length; i++ {
+			var v uint64
+			if col.typ == arrowFloat64 {
+				v = math.Float64bits(col.floats[i])
+			} else {
+				v = uint64(col.values[i])
+			}
+			binary.LittleEndian.PutUint64(data[8*i:], v)
+		}
+		appendBuffer(data)
+	}
+
+	b := &fbBuilder{}
+	nodeVec := b.createStructVector(len(nodes), 16, func(i int) {
+		b.prependInt64(nodes[i].nullCount)
+		b.prependInt64(nodes[i].length)
+	})
+	bufferVec := b.createStructVector(len(buffers), 16, func(i int) {
+		b.prependInt64(buffers[i].length)
+		b.prependInt64(buffers[i].offset)
+	})
+
+	b.startTable(3)
+	b.addInt64(0, int64(length))
+	b.addOffset(1, nodeVec)
+	b.addOffset(2, bufferVec)
+	batch := b.endTable()
+
+	return arrowMessage(b, arrowHeaderRecord, batch, len(body)), body
+}
+
+// arrowMessage wraps a schema or record batch header in a Message table and
+// finishes the buffer
+func arrowMessage(b *fbBuilder, headerType byte, header, bodyLength int) []byte {
+	b.startTable(4)
+	b.addInt16(0, arrowMetadataV5)
+	b.addUint8(1, headerType)
+	b.addOffset(2, header)
+	b.addInt64(3, int64(bodyLength))
+	return b.finish(b.endTable())
+}
+
+// fbBuilder builds a flatbuffer back to front, as the reference
+// implementation does, so that every offset points forward. Positions are
+// measured from the end of the buffer.
+type fbBuilder struct {
+	buf         []byte // buf[0] is the most recently written byte
+	vtable      []int  // positions of the fields of the table being built
+	objectStart int
+}
+
+func (b *fbBuilder) offset() int { return len(b.buf) }
+
+func (b *fbBuilder) prepend(p ...byte) {
+	b.buf = append(p, b.buf...)
+}
+
+// prep pads so that after writing extra more bytes, the position is a
+// multiple of align
+func (b *fbBuilder) prep(align, extra int) {
+	for (len(b.buf)+extra)%align != 0 {
+		b.prepend(0)
+	}
+}
+
+func (b *fbBuilder) prependUint16(v uint16) {
+	b.prep(2, 0)
+	b.prepend(byte(v), byte(v>>8))
+}
+
+func (b *fbBuilder) prependUint32(v uint32) {
+	b.prep(4, 0)
+	var p [4]byte
+	binary.LittleEndian.PutUint32(p[:], v)
+	b.prepend(p[:]...)
+}
+
+func (b *fbBuilder) prependInt64(v int64) {
+	b.prep(8, 0)
+	var p [8]byte
+	binary.LittleEndian.PutUint64(p[:], uint64(v))
+	b.prepend(p[:]...)
+}
+
+// prependOffset writes a uoffset to the object at position off
+func (b *fbBuilder) prependOffset(off int) {
+	b.prep(4, 0)
+	b.prependUint32(uint32(b.offset() + 4 - off))
+}
+
+func (b *fbBuilder) createString(s string) int {
+	b.prep(4, len(s)+1)
+	b.prepend(0)
+	b.prepend([]byte(s)...)
+	b.prependUint32(uint32(len(s)))
+	return b.offset()
+}
+
+func (b *fbBuilder) createOffsetVector(offs []int) int {
+	b.prep(4, 4*len(offs))
+	for i := len(offs) - 1; i >= 0; i-- {
+		b.prependOffset(offs[i])
+	}
+	b.prependUint32(uint32(len(offs)))
+	return b.offset()
+}
+
+// createStructVector writes n structs of size bytes, 8-byte aligned, with
+// write prepending the fields of struct i in reverse order
+func (b *fbBuilder) createStructVector(n, size int, write func(i int)) int {
+	b.prep(4, size*n)
+	b.prep(8, size*n)
+	for i := n - 1; i >= 0; i-- {
+		write(i)
+	}
+	b.prependUint32(uint32(n))
+	return b.offset()
+}
+
+func (b *fbBuilder) startTable(numFields int) {
+	b.vtable = make([]int, numFields)
+	b.objectStart = b.offset()
+}
+
+func (b *fbBuilder) addUint8(slot int, v byte) {
+	b.prepend(v)
+	b.vtable[slot] = b.offset()
+}
+
+func (b *fbBuilder) addBool(slot int, v bool) {
+	var x byte
+	if v {
+		x = 1
+	}
+	b.addUint8(slot, x)
+}
+
+func (b *fbBuilder) addInt16(slot int, v int16) {
+	b.prependUint16(uint16(v))
+	b.vtable[slot] = b.offset()
+}
+
+func (b *fbBuilder) addInt32(slot int, v int32) {
+	b.prependUint32(uint32(v))
+	b.vtable[slot] = b.offset()
+}
+
+func (b *fbBuilder) addInt64(slot int, v int64) {
+	b.prependInt64(v)
+	b.vtable[slot] = b.offset()
+}
+
+func (b *fbBuilder) addOffset(slot, off int) {
+	b.prependOffset(off)
+	b.vtable[slot] = b.offset()
+}
+
+// endTable writes the table's vtable just before it and returns the
+// table's position
+func (b *fbBuilder) endTable() int {
+	b.prependUint32(0) // soffset to the vtable, patched below
+	table := b.offset()
+
+	for i := len(b.vtable) - 1; i >= 0; i-- {
+		var fieldOffset uint16
+		if b.vtable[i] != 0 {
+			fieldOffset = uint16(table - b.vtable[i])
+		}
+		b.prependUint16(fieldOffset)
+	}
+	b.prependUint16(uint16(table - b.objectStart))
+	b.prependUint16(uint16(4 + 2*len(b.vtable)))
+	vtable := b.offset()
+
+	// The vtable precedes the table, so the soffset (table - vtable) is
+	// positive
+	binary.LittleEndian.PutUint32(b.buf[len(b.buf)-table:], uint32(vtable-table))
+	return table
+}
+
+// finish writes the root offset, padding the buffer to a multiple of 8
+// bytes so alignment measured from the end holds from the start too
+func (b *fbBuilder) finish(root int) []byte {
+	b.prep(8, 4)
+	b.prependOffset(root)
+	return b.buf
+}
@@ -0,0 +1,269 @@
+package gdelt
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores raw API responses keyed by CacheKey.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the cached body for key, if present and not expired
+	Get(key string) ([]byte, bool)
+	// Set stores body under key for ttl
+	Set(key string, body []byte, ttl time.Duration)
+}
+
+// CacheTTL controls how long responses are cached. Queries over a relative
+// Timespan change as time moves on, while a StartDate/EndDate window that
+// ended a while ago is effectively immutable. One that has only just ended
+// isn't yet, as GDELT's next update can still add articles seen during it.
+type CacheTTL struct {
+	Relative time.Duration // TTL for Timespan queries and windows that haven't settled
+	Fixed    time.Duration // TTL for StartDate/EndDate windows that have settled
+	// Settle is how long after EndDate a window is still treated as
+	// relative; zero means UpdateInterval
+	Settle time.Duration
+}
+
+// DefaultCacheTTL caches relative queries for five minutes and fixed
+// windows for a day, once they ended at least UpdateInterval ago
+func DefaultCacheTTL() CacheTTL {
+	return CacheTTL{
+		Relative: 5 * time.Minute,
+		Fixed:    24 * time.Hour,
+		Settle:   UpdateInterval,
+	}
+}
+
+// forFilters picks the TTL for a query built from filters
+func (t CacheTTL) forFilters(filters *Filters, now time.Time) time.Duration {
+	settle := t.Settle
+	if settle <= 0 {
+		settle = UpdateInterval
+	}
+	if filters.EndDate != nil && filters.EndDate.Before(now.Add(-settle)) {
+		return t.Fixed
+	}
+	return t.Relative
+}
+
+// CacheKey returns the canonical cache key for a query string built by
+// Filters.BuildQueryString, sent in mode to the API at baseURL. Whitespace
+// in the query expression is normalised and the URL parameters are sorted,
+// so equivalent filters map to the same key. Keys for different endpoints
+// never collide, so one cache can be shared by clients of several mirrors.
+func CacheKey(baseURL, mode, queryString string) string {
+	expr, params, _ := strings.Cut(queryString, "&")
+	expr = strings.Join(strings.Fields(expr), " ")
+
+	var sorted []string
+	if params != "" {
+		sorted = strings.Split(params, "&")
+		sort.Strings(sorted)
+	}
+
+	return baseURL + "?mode=" + mode + "&query=" + expr + "&" + strings.Join(sorted, "&")
+}
+
+// MemoryCache is an in-memory LRU cache with per-entry expiry
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+	now        func() time.Time
+}
+
+type memoryEntry struct {
+	key     string
+	body    []byte
+	expires time.Time
+}
+
+// NewMemoryCache creates an LRU cache holding at most maxEntries responses
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	if maxEntries < 1 {
+		maxEntries = 1
+	}
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+		now:        time.Now,
+	}
+}
+
+// Get returns the cached body for key, if present and not expired
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*memoryEntry)
+	if !m.now().Before(entry.expires) {
+		m.ll.Remove(el)
+		delete(m.items, key)
+		return nil, false
+	}
+
+	m.ll.MoveToFront(el)
+	return entry.body, true
+}
+
+// Set stores body under key for ttl, evicting the least recently used
+// entry when the cache is full
+func (m *MemoryCache) Set(key string, body []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expires := m.now().Add(ttl)
+	if el, ok := m.items[key]; ok {
+		entry := el.Value.(*memoryEntry)
+		entry.body = body
+		entry.expires = expires
+		m.ll.MoveToFront(el)
+		return
+	}
+
+	m.items[key] = m.ll.PushFront(&memoryEntry{key: key, body: body, expires: expires})
+	for m.ll.Len() > m.maxEntries {
+		oldest := m.ll.Back()
+		m.ll.Remove(oldest)
+		delete(m.items, oldest.Value.(*memoryEntry).key)
+	}
+}
+
+// Len returns the number of cached entries, including expired ones that
+// have not been evicted yet
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ll.Len()
+}
+
+// DiskCache stores responses as files in a directory, one file per key.
+// Each file holds the expiry time on its first line followed by the body.
+type DiskCache struct {
+	dir string
+	now func() time.Time
+}
+
+// NewDiskCache creates a cache in dir, creating the directory if needed
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &DiskCache{dir: dir, now: time.Now}, nil
+}
+
+// Get returns the cached body for key, if present and not expired
+func (d *DiskCache) Get(key string) ([]byte, bool) {
+	path := d.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	header, body, ok := bytes.Cut(data, []byte("\n"))
+	if !ok {
+		return nil, false
+	}
+	expires, err := strconv.ParseInt(string(header), 10, 64)
+	if err != nil || !d.now().Before(time.Unix(0, expires)) {
+		os.Remove(path)
+		return nil, false
+	}
+
+	return body, true
+}
+
+// Set stores body under key for ttl. Write errors are ignored; a failed
+// write only costs a cache miss later.
+func (d *DiskCache) Set(key string, body []byte, ttl time.Duration) {
+	_ = d.write(key, body, d.now().Add(ttl))
+}
+
+// write atomically replaces the file for key
+func (d *DiskCache) write(key string, body []byte, expires time.Time) error {
+	tmp, err := os.CreateTemp(d.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = fmt.Fprintf(tmp, "%d\n", expires.UnixNano())
+	if err == nil {
+		_, err = tmp.Write(body)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), d.path(key))
+}
+
+// Purge removes expired entries from the cache directory
+func (d *DiskCache) Purge() error {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	now := d.now()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".cache") {
+			continue
+		}
+		path := filepath.Join(d.dir, entry.Name())
+		if expired, err := d.expired(path, now); err != nil || expired {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// expired reads only the header line of a cache file
+func (d *DiskCache) expired(path string, now time.Time) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	buf := make([]byte, 32)
+	n, _ := f.Read(buf)
+	header, _, _ := bytes.Cut(buf[:n], []byte("\n"))
+	expires, err := strconv.ParseInt(string(header), 10, 64)
+	if err != nil {
+		return true, nil
+	}
+	return !now.Before(time.Unix(0, expires)), nil
+}
+
+// path maps a key to a file name safe for any file system
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".cache")
+}
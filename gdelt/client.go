@@ -2,131 +2,305 @@ package gdelt
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 	"time"
 )
 
 const (
-	// API Base URL
-	baseURL = "https://api.gdeltproject.org/api/v2/doc/doc"
+	// DefaultBaseURL is the GDELT DOC 2.0 API endpoint
+	DefaultBaseURL = "https://api.gdeltproject.org/api/v2/doc/doc"
 	// Version
 	version = "1.0.0"
-	// User agent
-	userAgent = "GDELT Go API client " + version + " - https://github.com/tri/gdelt"
+	// DefaultUserAgent is sent with every request unless overridden
+	DefaultUserAgent = "GDELT Go API client " + version + " - https://github.com/tri/gdelt"
 )
 
 // Client is the GDELT API client
 type Client struct {
 	httpClient     *http.Client
+	baseURL        string
+	userAgent      string
+	logger         *log.Logger
 	jsonParseDepth int
+	retry          RetryPolicy
+	limiter        *RateLimiter
+	cache          Cache
+	cacheTTL       CacheTTL
+	lenientThemes  bool
+	now            func() time.Time
 }
 
-// NewClient creates a new GDELT API client
-func NewClient() *Client {
-	return &Client{
+// NewClient creates a new GDELT API client configured by opts
+func NewClient(opts ...Option) *Client {
+	c := &Client{
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		baseURL:        DefaultBaseURL,
+		userAgent:      DefaultUserAgent,
 		jsonParseDepth: 100,
+		retry:          DefaultRetryPolicy(),
+		limiter:        DefaultRateLimiter(),
+		now:            time.Now,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // NewClientWithHTTP creates a new GDELT API client with a custom HTTP client
 func NewClientWithHTTP(client *http.Client) *Client {
-	return &Client{
-		httpClient:     client,
-		jsonParseDepth: 100,
-	}
+	return NewClient(WithHTTPClient(client))
 }
 
-// SetJSONParseDepth sets the maximum depth for JSON parsing cleanup
+// SetJSONParseDepth sets the deepest nesting the JSON repairer accepts
 func (c *Client) SetJSONParseDepth(depth int) {
 	c.jsonParseDepth = depth
 }
 
+// SetRetryPolicy sets the policy used to retry transient API errors
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retry = policy
+}
+
+// SetRateLimiter sets the limiter every request waits on before being sent.
+// Passing nil disables client-side rate limiting.
+func (c *Client) SetRateLimiter(limiter *RateLimiter) {
+	c.limiter = limiter
+}
+
+// RateLimiterStats returns statistics of the client's rate limiter
+func (c *Client) RateLimiterStats() RateLimiterStats {
+	if c.limiter == nil {
+		return RateLimiterStats{}
+	}
+	return c.limiter.Stats()
+}
+
+// SetCache sets the cache used for API responses, with ttl controlling how
+// long relative and fixed-window queries are kept. Passing a nil cache
+// disables caching.
+func (c *Client) SetCache(cache Cache, ttl CacheTTL) {
+	c.cache = cache
+	c.cacheTTL = ttl
+}
+
 // API modes
 const (
-	ModeArtList            = "artlist"
-	ModeTimelineVol        = "timelinevol"
-	ModeTimelineVolRaw     = "timelinevolraw"
-	ModeTimelineTone       = "timelinetone"
-	ModeTimelineLang       = "timelinelang"
+	ModeArtList               = "artlist"
+	ModeTimelineVol           = "timelinevol"
+	ModeTimelineVolRaw        = "timelinevolraw"
+	ModeTimelineVolInfo       = "timelinevolinfo"
+	ModeTimelineTone          = "timelinetone"
+	ModeTimelineLang          = "timelinelang"
 	ModeTimelineSourceCountry = "timelinesourcecountry"
+	ModeToneChart             = "tonechart"
+	ModeImageCollage          = "imagecollage"
+	ModeImageCollageInfo      = "imagecollageinfo"
+	ModeImageGallery          = "imagegallery"
+	ModeImageCollageShare     = "imagecollageshare"
+	ModeWordCloudImageTags    = "wordcloudimagetags"
+	ModeWordCloudImageWebTags = "wordcloudimagewebtags"
 )
 
 // supportedModes validates API modes
 var supportedModes = map[string]bool{
-	ModeArtList:            true,
-	ModeTimelineVol:        true,
-	ModeTimelineVolRaw:     true,
-	ModeTimelineTone:       true,
-	ModeTimelineLang:       true,
+	ModeArtList:               true,
+	ModeTimelineVol:           true,
+	ModeTimelineVolRaw:        true,
+	ModeTimelineVolInfo:       true,
+	ModeTimelineTone:          true,
+	ModeTimelineLang:          true,
+	ModeTimelineSourceCountry: true,
+	ModeToneChart:             true,
+	ModeImageCollage:          true,
+	ModeImageCollageInfo:      true,
+	ModeImageGallery:          true,
+	ModeImageCollageShare:     true,
+	ModeWordCloudImageTags:    true,
+	ModeWordCloudImageWebTags: true,
+}
+
+// timelineModes lists the modes TimelineSearch accepts
+var timelineModes = map[string]bool{
+	ModeTimelineVol:           true,
+	ModeTimelineVolRaw:        true,
+	ModeTimelineVolInfo:       true,
+	ModeTimelineTone:          true,
+	ModeTimelineLang:          true,
 	ModeTimelineSourceCountry: true,
 }
 
 // ArticleSearch performs an article list search
 func (c *Client) ArticleSearch(filters *Filters) ([]Article, error) {
-	result, err := c.query(ModeArtList, filters)
+	return c.ArticleSearchContext(context.Background(), filters)
+}
+
+// ArticleSearchContext performs an article list search, aborting the
+// upstream request when ctx is cancelled or its deadline passes
+func (c *Client) ArticleSearchContext(ctx context.Context, filters *Filters) ([]Article, error) {
+	list, err := c.ArticleListContext(ctx, filters)
 	if err != nil {
 		return nil, err
 	}
+	return list.Articles, nil
+}
 
-	articles, ok := result["articles"]
-	if !ok {
-		return []Article{}, nil
-	}
-
-	// Marshal and unmarshal to convert to Article struct
-	data, err := json.Marshal(articles)
+// ArticleListContext performs an article list search like
+// ArticleSearchContext, also reporting any repairs made to the response
+func (c *Client) ArticleListContext(ctx context.Context, filters *Filters) (*ArticleList, error) {
+	var articles []Article
+	warnings, err := c.query(ctx, ModeArtList, filters, func(dec *json.Decoder) error {
+		var err error
+		articles, err = decodeArticleList(dec)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal articles: %w", err)
-	}
-
-	var articleList []Article
-	if err := json.Unmarshal(data, &articleList); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal articles: %w", err)
+		return nil, err
 	}
 
-	return articleList, nil
+	return &ArticleList{Articles: articles, Warnings: warnings}, nil
 }
 
 // TimelineSearch performs a timeline search in the specified mode
 func (c *Client) TimelineSearch(mode string, filters *Filters) (*TimelineResult, error) {
-	if !supportedModes[mode] {
+	return c.TimelineSearchContext(context.Background(), mode, filters)
+}
+
+// TimelineSearchContext performs a timeline search in the specified mode,
+// aborting the upstream request when ctx is cancelled or its deadline passes
+func (c *Client) TimelineSearchContext(ctx context.Context, mode string, filters *Filters) (*TimelineResult, error) {
+	if !timelineModes[mode] {
 		return nil, fmt.Errorf("mode %s is not supported", mode)
 	}
 
-	result, err := c.query(mode, filters)
+	var resp TimelineResponse
+	warnings, err := c.query(ctx, mode, filters, decodeInto(&resp))
 	if err != nil {
 		return nil, err
 	}
 
-	// Marshal and unmarshal to convert to TimelineResponse
-	data, err := json.Marshal(result)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal timeline: %w", err)
-	}
-
-	var resp TimelineResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal timeline: %w", err)
-	}
-
 	// Check for empty results
 	if len(resp.Timeline) == 0 || len(resp.Timeline[0].Data) == 0 {
 		return &TimelineResult{
 			QueryDetails: resp.QueryDetails,
 			Rows:        []TimelineRow{},
 			SeriesNames: []string{},
+			Warnings:     warnings,
 		}, nil
 	}
 
-	return c.processTimeline(mode, resp)
+	result, err := c.processTimeline(mode, resp)
+	if err != nil {
+		return nil, err
+	}
+	result.Warnings = warnings
+	return result, nil
+}
+
+// ToneChartSearch returns the histogram of article tone for the filters
+func (c *Client) ToneChartSearch(filters *Filters) (*ToneChart, error) {
+	return c.ToneChartSearchContext(context.Background(), filters)
+}
+
+// ToneChartSearchContext returns the histogram of article tone for the
+// filters, aborting the upstream request when ctx is cancelled
+func (c *Client) ToneChartSearchContext(ctx context.Context, filters *Filters) (*ToneChart, error) {
+	var resp toneChartResponse
+	warnings, err := c.query(ctx, ModeToneChart, filters, decodeInto(&resp))
+	if err != nil {
+		return nil, err
+	}
+
+	chart := ToneChart{Bins: resp.Bins, Warnings: warnings}
+	if chart.Bins == nil {
+		chart.Bins = []ToneBin{}
+	}
+
+	sort.Slice(chart.Bins, func(i, j int) bool {
+		return chart.Bins[i].Bin < chart.Bins[j].Bin
+	})
+
+	return &chart, nil
+}
+
+// imageModes lists the modes ImageSearch accepts
+var imageModes = map[string]bool{
+	ModeImageCollage:      true,
+	ModeImageCollageInfo:  true,
+	ModeImageGallery:      true,
+	ModeImageCollageShare: true,
+}
+
+// ImageSearch returns the images matching the filters in the given image mode
+func (c *Client) ImageSearch(mode string, filters *Filters) (*ImageList, error) {
+	return c.ImageSearchContext(context.Background(), mode, filters)
+}
+
+// ImageSearchContext returns the images matching the filters in the given
+// image mode, aborting the upstream request when ctx is cancelled
+func (c *Client) ImageSearchContext(ctx context.Context, mode string, filters *Filters) (*ImageList, error) {
+	if !imageModes[mode] {
+		return nil, fmt.Errorf("mode %s is not an image mode", mode)
+	}
+
+	var resp imageResponse
+	warnings, err := c.query(ctx, mode, filters, decodeInto(&resp))
+	if err != nil {
+		return nil, err
+	}
+
+	list := ImageList{Images: resp.Images, Warnings: warnings}
+	if list.Images == nil {
+		list.Images = []Image{}
+	}
+	return &list, nil
+}
+
+// wordCloudModes lists the modes WordCloudSearch accepts
+var wordCloudModes = map[string]bool{
+	ModeWordCloudImageTags:    true,
+	ModeWordCloudImageWebTags: true,
+}
+
+// WordCloudSearch returns the term frequencies of the given word cloud mode,
+// ordered from most to least frequent
+func (c *Client) WordCloudSearch(mode string, filters *Filters) (*WordCloud, error) {
+	return c.WordCloudSearchContext(context.Background(), mode, filters)
+}
+
+// WordCloudSearchContext returns the term frequencies of the given word
+// cloud mode, aborting the upstream request when ctx is cancelled
+func (c *Client) WordCloudSearchContext(ctx context.Context, mode string, filters *Filters) (*WordCloud, error) {
+	if !wordCloudModes[mode] {
+		return nil, fmt.Errorf("mode %s is not a word cloud mode", mode)
+	}
+
+	var resp wordCloudResponse
+	warnings, err := c.query(ctx, mode, filters, decodeInto(&resp))
+	if err != nil {
+		return nil, err
+	}
+
+	cloud := WordCloud{Terms: resp.Terms, Warnings: warnings}
+	if cloud.Terms == nil {
+		cloud.Terms = []TermWeight{}
+	}
+
+	sort.SliceStable(cloud.Terms, func(i, j int) bool {
+		return cloud.Terms[i].Weight > cloud.Terms[j].Weight
+	})
+
+	return &cloud, nil
 }
 
 // processTimeline converts the timeline response to TimelineResult
@@ -166,6 +340,11 @@ func (c *Client) processTimeline(mode string, resp TimelineResponse) (*TimelineR
 			}
 		}
 
+		// For timelinevolinfo, carry the top articles of each time bucket
+		if mode == ModeTimelineVolInfo {
+			row.TopArticles = resp.Timeline[0].Data[i].TopArticles
+		}
+
 		rows[i] = row
 	}
 
@@ -176,26 +355,101 @@ func (c *Client) processTimeline(mode string, resp TimelineResponse) (*TimelineR
 	}, nil
 }
 
-// query performs a raw query to the API
-func (c *Client) query(mode string, filters *Filters) (map[string]interface{}, error) {
+// query performs a raw query to the API, handing the response body to
+// decode. It returns the repairs needed to make the body decodable.
+func (c *Client) query(ctx context.Context, mode string, filters *Filters, decode func(*json.Decoder) error) ([]RepairWarning, error) {
 	if !supportedModes[mode] {
 		return nil, fmt.Errorf("unsupported mode: %s", mode)
 	}
 
-	queryString, err := filters.BuildQueryString()
+	queryString, err := filters.buildQueryString(c.lenientThemes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build query: %w", err)
 	}
+	if c.lenientThemes {
+		for _, err := range filters.uncataloguedThemes() {
+			c.logf("%v; sending it anyway", err)
+		}
+	}
+	// A window the API can't search would only come back empty
+	if filters.StartDate != nil && filters.EndDate != nil {
+		if err := validateWindow(*filters.StartDate, *filters.EndDate, c.now()); err != nil {
+			return nil, fmt.Errorf("failed to build query: %w", err)
+		}
+	}
 
 	// Build URL with proper URL encoding
-	url := fmt.Sprintf("%s?query=%s&mode=%s&format=json", baseURL, url.PathEscape(queryString), mode)
+	requestURL := fmt.Sprintf("%s?query=%s&mode=%s&format=json", c.baseURL, url.PathEscape(queryString), mode)
+
+	// Serve identical queries from the cache when one is configured
+	key := CacheKey(c.baseURL, mode, queryString)
+	if c.cache != nil {
+		if body, ok := c.cache.Get(key); ok {
+			if warnings, err := c.parseJSON(body, decode); err == nil {
+				return warnings, nil
+			}
+			c.logf("ignoring unparseable cache entry for %s", key)
+		}
+	}
+
+	body, err := c.fetch(ctx, requestURL)
+	if err != nil {
+		return nil, err
+	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	// Parse JSON, repairing the body if GDELT sent it malformed
+	warnings, err := c.parseJSON(body, decode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	for _, w := range warnings {
+		c.logf("repaired response for %s: %s", key, w)
+	}
+
+	if c.cache != nil {
+		if ttl := c.cacheTTL.forFilters(filters, c.now()); ttl > 0 {
+			c.cache.Set(key, body, ttl)
+		}
+	}
+
+	return warnings, nil
+}
+
+// fetch performs the request, waiting on the rate limiter before every
+// attempt and retrying transient errors according to the retry policy
+func (c *Client) fetch(ctx context.Context, requestURL string) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		body, err := c.doRequest(ctx, requestURL)
+
+		delay, retry := c.retry.next(attempt, err)
+		if c.retry.OnAttempt != nil {
+			c.retry.OnAttempt(RetryAttempt{Attempt: attempt, Err: err, Delay: delay, WillRetry: retry})
+		}
+		if !retry {
+			return body, err
+		}
+		c.logf("attempt %d failed, retrying in %s: %v", attempt, delay, err)
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// doRequest performs a single GET against the API and returns the body
+func (c *Client) doRequest(ctx context.Context, requestURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("User-Agent", c.userAgent)
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := c.httpClient.Do(req)
@@ -211,7 +465,7 @@ func (c *Client) query(mode string, filters *Filters) (map[string]interface{}, e
 
 	// Check for HTTP errors
 	if err := checkResponseError(resp.StatusCode, body); err != nil {
-		return nil, err
+		return nil, withRetryAfter(err, resp.Header.Get("Retry-After"))
 	}
 
 	// Sometimes API returns text/html for invalid requests
@@ -220,82 +474,37 @@ func (c *Client) query(mode string, filters *Filters) (map[string]interface{}, e
 		return nil, fmt.Errorf("the query was not valid. The API error message was: %s", strings.TrimSpace(string(body)))
 	}
 
-	// Parse JSON with cleanup for illegal characters
-	var result map[string]interface{}
-	if err := c.parseJSON(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON: %w", err)
-	}
-
-	return result, nil
+	return body, nil
 }
 
-// parseJSON tries to parse JSON, removing illegal characters if needed
-func (c *Client) parseJSON(data []byte, result interface{}) error {
-	decoder := json.NewDecoder(bytes.NewReader(data))
-	decoder.DisallowUnknownFields()
+// logf logs through the configured logger, if any
+func (c *Client) logf(format string, args ...interface{}) {
+	if c.logger != nil {
+		c.logger.Printf(format, args...)
+	}
+}
 
-	err := decoder.Decode(result)
+// parseJSON runs decode over data, repairing malformed JSON and trying
+// again if needed
+func (c *Client) parseJSON(data []byte, decode func(*json.Decoder) error) ([]RepairWarning, error) {
+	err := decode(json.NewDecoder(bytes.NewReader(data)))
 	if err == nil {
-		return nil
+		return nil, nil
 	}
 
-	// Try to fix common JSON issues by recursively cleaning
-	var cleanErr error
-	for depth := 0; depth < c.jsonParseDepth; depth++ {
-		// Try to find and fix the issue
-		cleaned := c.cleanJSON(string(data))
-		if cleaned == string(data) {
-			// No changes made, stop trying
-			break
-		}
-
-		decoder = json.NewDecoder(bytes.NewReader([]byte(cleaned)))
-		decoder.DisallowUnknownFields()
-		err = decoder.Decode(result)
-		if err == nil {
-			return nil
-		}
-		cleanErr = err
-		data = []byte(cleaned)
+	repaired, warnings, repairErr := repairJSON(data, c.jsonParseDepth)
+	if repairErr != nil {
+		return nil, fmt.Errorf("%w (repair failed: %v)", err, repairErr)
+	}
+	if len(warnings) == 0 {
+		// Nothing to repair, so the body is valid JSON of the wrong shape
+		return nil, err
 	}
 
-	return cleanErr
-}
-
-// cleanJSON removes problematic characters from JSON
-func (c *Client) cleanJSON(jsonStr string) string {
-	// This is a simple cleaner that removes some common issues
-	// For a more robust solution, you might need a more sophisticated approach
-	result := strings.ReplaceAll(jsonStr, "\x00", "")
-	result = strings.ReplaceAll(result, "\x01", "")
-	result = strings.ReplaceAll(result, "\x02", "")
-	result = strings.ReplaceAll(result, "\x03", "")
-	result = strings.ReplaceAll(result, "\x04", "")
-	result = strings.ReplaceAll(result, "\x05", "")
-	result = strings.ReplaceAll(result, "\x06", "")
-	result = strings.ReplaceAll(result, "\x07", "")
-	result = strings.ReplaceAll(result, "\x08", "")
-	result = strings.ReplaceAll(result, "\x0B", "")
-	result = strings.ReplaceAll(result, "\x0C", "")
-	result = strings.ReplaceAll(result, "\x0E", "")
-	result = strings.ReplaceAll(result, "\x0F", "")
-	result = strings.ReplaceAll(result, "\x10", "")
-	result = strings.ReplaceAll(result, "\x11", "")
-	result = strings.ReplaceAll(result, "\x12", "")
-	result = strings.ReplaceAll(result, "\x13", "")
-	result = strings.ReplaceAll(result, "\x14", "")
-	result = strings.ReplaceAll(result, "\x15", "")
-	result = strings.ReplaceAll(result, "\x16", "")
-	result = strings.ReplaceAll(result, "\x17", "")
-	result = strings.ReplaceAll(result, "\x18", "")
-	result = strings.ReplaceAll(result, "\x19", "")
-	result = strings.ReplaceAll(result, "\x1A", "")
-	result = strings.ReplaceAll(result, "\x1B", "")
-	result = strings.ReplaceAll(result, "\x1C", "")
-	result = strings.ReplaceAll(result, "\x1D", "")
-	result = strings.ReplaceAll(result, "\x1E", "")
-	result = strings.ReplaceAll(result, "\x1F", "")
-	return result
+	if err := decode(json.NewDecoder(bytes.NewReader(repaired))); err != nil {
+		return warnings, err
+	}
+	return warnings, nil
 }
 
 // checkResponseError converts HTTP status codes to appropriate errors
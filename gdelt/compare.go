@@ -0,0 +1,211 @@
+package gdelt
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCompareConcurrency is how many queries of a comparison run at once
+// unless CompareOptions says otherwise. Requests still pass through the
+// client's rate limiter.
+const DefaultCompareConcurrency = 4
+
+// LabeledFilters is one query of a timeline comparison
+type LabeledFilters struct {
+	Label   string
+	Filters *Filters
+}
+
+// GapFill controls what a comparison puts where a query has no value for a
+// timestamp another query has
+type GapFill int
+
+const (
+	GapFillZero     GapFill = iota // Fill with 0, right for volume modes
+	GapFillPrevious                // Carry the query's previous value forward
+	GapFillNone                    // Leave the value missing from the row
+)
+
+// CompareOptions controls CompareTimelinesContext
+type CompareOptions struct {
+	Concurrency int     // Queries in flight at once; 0 means DefaultCompareConcurrency
+	Fill        GapFill // How gaps in the shared time axis are filled
+}
+
+// CompareTimelines runs labelled timeline queries and aligns them on a
+// shared time axis
+func (c *Client) CompareTimelines(mode string, queries []LabeledFilters, opts CompareOptions) (*TimelineResult, error) {
+	return c.CompareTimelinesContext(context.Background(), mode, queries, opts)
+}
+
+// CompareTimelinesContext runs the timeline query of each LabeledFilters in
+// mode, at most opts.Concurrency at a time, and joins the results into one
+// TimelineResult. Its rows cover every timestamp any query returned. A
+// query's series is named after its label, or "label/series" when the
+// mode returns several series per query (e.g. timelinelang). Gaps are
+// filled according to opts.Fill. The first failing query cancels the rest
+// and its error is returned.
+func (c *Client) CompareTimelinesContext(ctx context.Context, mode string, queries []LabeledFilters, opts CompareOptions) (*TimelineResult, error) {
+	if !timelineModes[mode] {
+		return nil, fmt.Errorf("mode %s is not supported", mode)
+	}
+	if err := validateLabels(queries); err != nil {
+		return nil, err
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultCompareConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*TimelineResult, len(queries))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	// The first failure cancels the other queries; their cancellation
+	// errors aren't worth reporting
+	var mu sync.Mutex
+	var firstErr error
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+
+	for i, q := range queries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			case <-ctx.Done():
+				return
+			}
+
+			result, err := c.TimelineSearchContext(ctx, mode, q.Filters)
+			if err != nil {
+				fail(fmt.Errorf("query %q: %w", q.Label, err))
+				return
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return alignTimelines(queries, results, opts.Fill), nil
+}
+
+// validateLabels checks that every query has a filter and a unique label.
+// Labels can't contain "/", which separates them from the series name, so
+// that combined series names can't collide.
+func validateLabels(queries []LabeledFilters) error {
+	if len(queries) == 0 {
+		return &ValidationError{Field: "Label", Reason: "at least one query is required"}
+	}
+	seen := make(map[string]bool, len(queries))
+	for _, q := range queries {
+		if q.Label == "" {
+			return &ValidationError{Field: "Label", Reason: "must not be empty"}
+		}
+		if strings.Contains(q.Label, "/") {
+			return &ValidationError{Field: "Label", Reason: fmt.Sprintf("label %q must not contain \"/\"", q.Label)}
+		}
+		if seen[q.Label] {
+			return &ValidationError{Field: "Label", Reason: fmt.Sprintf("duplicate label %q", q.Label)}
+		}
+		seen[q.Label] = true
+		if q.Filters == nil {
+			return &ValidationError{Field: "Filters", Reason: fmt.Sprintf("query %q has no filters", q.Label)}
+		}
+	}
+	return nil
+}
+
+// alignTimelines joins results, one per query, on the union of their
+// timestamps
+func alignTimelines(queries []LabeledFilters, results []*TimelineResult, fill GapFill) *TimelineResult {
+	combined := &TimelineResult{}
+
+	// Name the combined series and index every query's rows by timestamp
+	type column struct {
+		name, series string
+		rows         map[time.Time]TimelineRow
+	}
+	var columns []column
+	seen := make(map[time.Time]bool)
+	var times []time.Time
+
+	for i, result := range results {
+		rows := make(map[time.Time]TimelineRow, len(result.Rows))
+		for _, row := range result.Rows {
+			t := row.DateTime.UTC()
+			rows[t] = row
+			if !seen[t] {
+				seen[t] = true
+				times = append(times, t)
+			}
+		}
+		// A query without results still gets its (empty) column
+		seriesNames := result.SeriesNames
+		if len(seriesNames) == 0 {
+			seriesNames = []string{""}
+		}
+		for _, series := range seriesNames {
+			name := queries[i].Label
+			if len(seriesNames) > 1 {
+				name += "/" + series
+			}
+			columns = append(columns, column{name, series, rows})
+			combined.SeriesNames = append(combined.SeriesNames, name)
+		}
+		combined.Warnings = append(combined.Warnings, result.Warnings...)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	combined.Rows = make([]TimelineRow, len(times))
+	previous := make(map[string]float64)
+	for i, t := range times {
+		row := TimelineRow{DateTime: t, Series: make(map[string]float64, len(columns))}
+		for _, col := range columns {
+			src, ok := col.rows[t]
+			v, has := src.Series[col.series]
+			switch {
+			case has:
+				previous[col.name] = v
+			case fill == GapFillZero:
+				v, has = 0, true
+			case fill == GapFillPrevious:
+				v, has = previous[col.name]
+			}
+			if has {
+				row.Series[col.name] = v
+			}
+
+			// The total article count doesn't depend on the query, so any
+			// query's will do
+			if ok && row.AllArticles == nil {
+				row.AllArticles = src.AllArticles
+			}
+		}
+		combined.Rows[i] = row
+	}
+
+	return combined
+}
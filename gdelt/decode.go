@@ -0,0 +1,92 @@
+package gdelt
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// toneChartResponse is the response from the tonechart mode
+type toneChartResponse struct {
+	Bins []ToneBin `json:"tonechart"`
+}
+
+// imageResponse is the response from the image modes
+type imageResponse struct {
+	Images []Image `json:"images"`
+}
+
+// wordCloudResponse is the response from the word cloud modes
+type wordCloudResponse struct {
+	Terms []TermWeight `json:"wordcloud"`
+}
+
+// decodeInto decodes a whole response into v
+func decodeInto(v interface{}) func(*json.Decoder) error {
+	return func(dec *json.Decoder) error {
+		return dec.Decode(v)
+	}
+}
+
+// decodeArticleList walks an artlist response token by token, decoding and
+// normalizing each article as it is reached and skipping every other
+// top-level field, so the response is never held as a generic value. A
+// response without an articles field yields an empty list.
+func decodeArticleList(dec *json.Decoder) ([]Article, error) {
+	articles := []Article{}
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		if key, _ := tok.(string); key != "articles" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		tok, err = dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		if tok == nil {
+			continue // "articles": null
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return nil, fmt.Errorf("expected articles array at offset %d, got %v", dec.InputOffset(), tok)
+		}
+		for dec.More() {
+			var article Article
+			if err := dec.Decode(&article); err != nil {
+				return nil, err
+			}
+			article.normalize()
+			articles = append(articles, article)
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return nil, err
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+// expectDelim consumes the next token, failing unless it is want
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if got, ok := tok.(json.Delim); !ok || got != want {
+		return fmt.Errorf("expected %q at offset %d, got %v", want, dec.InputOffset(), tok)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package gdelt
 
 import (
 	"fmt"
+	"time"
 )
 
 // ErrorCode represents different types of API errors
@@ -34,6 +35,8 @@ func (e *NotFoundError) Error() string {
 
 type RateLimitError struct {
 	Message string
+	// RetryAfter is the delay requested by the Retry-After header, if any
+	RetryAfter time.Duration
 }
 
 func (e *RateLimitError) Error() string {
@@ -52,6 +55,8 @@ func (e *ClientRequestError) Error() string {
 type ServerError struct {
 	StatusCode int
 	Message    string
+	// RetryAfter is the delay requested by the Retry-After header, if any
+	RetryAfter time.Duration
 }
 
 func (e *ServerError) Error() string {
@@ -66,3 +71,27 @@ type APIError struct {
 func (e *APIError) Error() string {
 	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Message)
 }
+
+// ValidationError reports a filter or builder argument that cannot be turned
+// into a valid query. Field names the offending Filters field (or builder
+// argument) so callers such as the server can point at it.
+type ValidationError struct {
+	Field  string
+	Reason string
+	// Err is the underlying error, if any, e.g. an *UnknownCodeError
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// invalid wraps err as a ValidationError for field, keeping err reachable
+// through errors.As
+func invalid(field string, err error) *ValidationError {
+	return &ValidationError{Field: field, Reason: err.Error(), Err: err}
+}
@@ -86,7 +86,7 @@ func main() {
 	// Example 4: Using timespan instead of dates
 	fmt.Println("=== Using Timespan ===")
 	timespanFilters := &gdelt.Filters{
-		Timespan:   "24h",
+		Timespan:   gdelt.MustParseTimespan("24h"),
 		Keyword:    "artificial intelligence",
 		NumRecords: 5,
 	}
@@ -107,10 +107,18 @@ func main() {
 
 	// Example 5: Using Near and Repeat filters
 	fmt.Println("=== Using Near and Repeat Filters ===")
+	near, err := gdelt.Near(5, "climate", "technology")
+	if err != nil {
+		log.Fatal(err)
+	}
+	repeat, err := gdelt.Repeat(3, "energy")
+	if err != nil {
+		log.Fatal(err)
+	}
 	nearRepeatFilters := &gdelt.Filters{
-		Timespan:   "7d",
-		Near:       gdelt.Near(5, "climate", "technology"),
-		Repeat:     gdelt.Repeat(3, "energy"),
+		Timespan:   gdelt.MustParseTimespan("7d"),
+		Near:       near,
+		Repeat:     repeat,
 		NumRecords: 5,
 	}
 
@@ -131,7 +139,7 @@ func main() {
 	// Example 6: Domain filter
 	fmt.Println("=== Domain Filter ===")
 	domainFilters := &gdelt.Filters{
-		Timespan:   "24h",
+		Timespan:   gdelt.MustParseTimespan("24h"),
 		Keyword:    "technology",
 		Domain:     "techcrunch.com",
 		NumRecords: 5,
@@ -153,7 +161,7 @@ func main() {
 	// Example 7: Tone filter (positive articles)
 	fmt.Println("\n=== Tone Filter (Positive) ===")
 	toneFilters := &gdelt.Filters{
-		Timespan:   "24h",
+		Timespan:   gdelt.MustParseTimespan("24h"),
 		Keyword:    "economy",
 		Tone:       string(gdelt.ToneGreater) + "5",
 		NumRecords: 5,
@@ -175,7 +183,7 @@ func main() {
 	// Example 8: Timeline Tone
 	fmt.Println("\n=== Timeline Tone ===")
 	timelineTone, err := client.TimelineSearch(gdelt.ModeTimelineTone, &gdelt.Filters{
-		Timespan: "24h",
+		Timespan: gdelt.MustParseTimespan("24h"),
 		Keyword:  "economy",
 	})
 	if err != nil {
@@ -0,0 +1,181 @@
+package gdelt
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// AllArticlesSeries is the column (or series, in long output) under which
+// exports carry TimelineRow.AllArticles, the total article count that
+// timelinevolraw reports alongside the matching count
+const AllArticlesSeries = "All Articles"
+
+// exportTimeFormat is how exports write TimelineRow.DateTime
+const exportTimeFormat = "2006-01-02T15:04:05Z"
+
+// ExportFormat selects the encoding written by TimelineResult.Export
+type ExportFormat string
+
+const (
+	ExportCSV   ExportFormat = "csv"   // Wide CSV, one column per series
+	ExportJSONL ExportFormat = "jsonl" // Long JSON Lines, one record per row and series
+	ExportArrow ExportFormat = "arrow" // Arrow IPC stream, wide like CSV
+)
+
+// ContentType returns the MIME type of the format
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case ExportCSV:
+		return "text/csv"
+	case ExportJSONL:
+		return "application/jsonl"
+	case ExportArrow:
+		return "application/vnd.apache.arrow.stream"
+	}
+	return "application/octet-stream"
+}
+
+// IsValid reports whether f is a supported export format
+func (f ExportFormat) IsValid() bool {
+	switch f {
+	case ExportCSV, ExportJSONL, ExportArrow:
+		return true
+	}
+	return false
+}
+
+// Export writes r to w in format
+func (r *TimelineResult) Export(w io.Writer, format ExportFormat) error {
+	switch format {
+	case ExportCSV:
+		return r.WriteCSV(w)
+	case ExportJSONL:
+		return r.WriteJSONL(w)
+	case ExportArrow:
+		return r.WriteArrow(w)
+	}
+	return fmt.Errorf("export format %q is not supported (must be one of: csv, jsonl, arrow)", string(format))
+}
+
+// hasAllArticles reports whether any row carries an AllArticles count, in
+// which case exports add an AllArticlesSeries column
+func (r *TimelineResult) hasAllArticles() bool {
+	for _, row := range r.Rows {
+		if row.AllArticles != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteCSV writes r as a wide CSV: a datetime column followed by one column
+// per series in SeriesNames order, plus AllArticlesSeries for
+// timelinevolraw. Values missing from a row are left empty.
+func (r *TimelineResult) WriteCSV(w io.Writer) error {
+	allArticles := r.hasAllArticles()
+
+	cw := csv.NewWriter(w)
+	header := append([]string{"datetime"}, r.SeriesNames...)
+	if allArticles {
+		header = append(header, AllArticlesSeries)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(header))
+	for _, row := range r.Rows {
+		record = record[:0]
+		record = append(record, row.DateTime.UTC().Format(exportTimeFormat))
+		for _, name := range r.SeriesNames {
+			if v, ok := row.Series[name]; ok {
+				record = append(record, strconv.FormatFloat(v, 'g', -1, 64))
+			} else {
+				record = append(record, "")
+			}
+		}
+		if allArticles {
+			if row.AllArticles != nil {
+				record = append(record, strconv.Itoa(*row.AllArticles))
+			} else {
+				record = append(record, "")
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// timelineRecord is one line of the long JSON Lines export
+type timelineRecord struct {
+	DateTime string  `json:"datetime"`
+	Series   string  `json:"series"`
+	Value    float64 `json:"value"`
+}
+
+// WriteJSONL writes r in long (tidy) form as JSON Lines: one
+// {"datetime", "series", "value"} object per row and series, in row order
+// and SeriesNames order within a row. AllArticles counts follow each row's
+// series under AllArticlesSeries. Missing values are skipped.
+func (r *TimelineResult) WriteJSONL(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for _, row := range r.Rows {
+		dt := row.DateTime.UTC().Format(exportTimeFormat)
+		for _, name := range r.SeriesNames {
+			if v, ok := row.Series[name]; ok {
+				if err := enc.Encode(timelineRecord{DateTime: dt, Series: name, Value: v}); err != nil {
+					return err
+				}
+			}
+		}
+		if row.AllArticles != nil {
+			if err := enc.Encode(timelineRecord{DateTime: dt, Series: AllArticlesSeries, Value: float64(*row.AllArticles)}); err != nil {
+				return err
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteArrow writes r as an Arrow IPC stream holding a single record batch
+// with the same columns as WriteCSV: a non-null timestamp[s, UTC]
+// "datetime" column, a nullable float64 column per series and, for
+// timelinevolraw, a nullable int64 AllArticlesSeries column.
+func (r *TimelineResult) WriteArrow(w io.Writer) error {
+	n := len(r.Rows)
+
+	times := make([]int64, n)
+	for i, row := range r.Rows {
+		times[i] = row.DateTime.Unix()
+	}
+	columns := []arrowColumn{{name: "datetime", typ: arrowTimestamp, values: times}}
+
+	for _, name := range r.SeriesNames {
+		col := arrowColumn{name: name, typ: arrowFloat64, nullable: true, valid: make([]bool, n), floats: make([]float64, n)}
+		for i, row := range r.Rows {
+			col.floats[i], col.valid[i] = row.Series[name]
+		}
+		columns = append(columns, col)
+	}
+
+	if r.hasAllArticles() {
+		col := arrowColumn{name: AllArticlesSeries, typ: arrowInt64, nullable: true, valid: make([]bool, n), values: make([]int64, n)}
+		for i, row := range r.Rows {
+			if row.AllArticles != nil {
+				col.values[i], col.valid[i] = int64(*row.AllArticles), true
+			}
+		}
+		columns = append(columns, col)
+	}
+
+	return writeArrowStream(w, n, columns)
+}
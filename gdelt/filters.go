@@ -3,69 +3,72 @@ package gdelt
 import (
 	"errors"
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/tri2820/gdelt/query"
 )
 
-// Common country codes (FIPS 2-letter codes)
+// Common country codes (FIPS 10-4). Constants are named after the ISO
+// 3166 code, so CountryDE is Germany, whose FIPS code is "GM"; see
+// Countries for the full list.
 const (
 	CountryUS          = "US"
 	CountryUK          = "UK"
 	CountryCA          = "CA"
-	CountryAU          = "AU"
-	CountryDE          = "DE"
+	CountryAU          = "AS"
+	CountryDE          = "GM"
 	CountryFR          = "FR"
-	CountryJP          = "JP"
-	CountryCN          = "CN"
+	CountryJP          = "JA"
+	CountryCN          = "CH"
 	CountryIN          = "IN"
 	CountryBR          = "BR"
-	CountryRU          = "RU"
-	CountryKR          = "KR"
+	CountryRU          = "RS"
+	CountryKR          = "KS"
 	CountryIT          = "IT"
-	CountryES          = "ES"
+	CountryES          = "SP"
 	CountryMX          = "MX"
 	CountryID          = "ID"
 	CountryNL          = "NL"
 	CountrySA          = "SA"
-	CountryCH          = "CH"
-	CountrySE          = "SE"
-	CountryTR          = "TR"
+	CountryCH          = "SZ"
+	CountrySE          = "SW"
+	CountryTR          = "TU"
 	CountryBE          = "BE"
 	CountryAR          = "AR"
 	CountryPL          = "PL"
-	CountryZA          = "ZA"
+	CountryZA          = "SF"
 	CountryTH          = "TH"
 	CountryEG          = "EG"
 	CountryMY          = "MY"
-	CountrySG          = "SG"
-	CountryPH          = "PH"
+	CountrySG          = "SN"
+	CountryPH          = "RP"
 	CountryPK          = "PK"
-	CountryBD          = "BD"
-	CountryNG          = "NG"
-	CountryVN          = "VN"
+	CountryBD          = "BG"
+	CountryNG          = "NI"
+	CountryVN          = "VM"
 	CountryIR          = "IR"
-	CountryCL          = "CL"
+	CountryCL          = "CI"
 	CountryFI          = "FI"
-	CountryDK          = "DK"
+	CountryDK          = "DA"
 	CountryNO          = "NO"
-	CountryIE          = "IE"
-	CountryAT          = "AT"
-	CountryIL          = "IL"
+	CountryIE          = "EI"
+	CountryAT          = "AU"
+	CountryIL          = "IS"
 	CountryNZ          = "NZ"
 	CountryGR          = "GR"
-	CountryPT          = "PT"
-	CountryCZ          = "CZ"
+	CountryPT          = "PO"
+	CountryCZ          = "EZ"
 	CountryHU          = "HU"
-	CountryUA          = "UA"
+	CountryUA          = "UP"
 	CountryHK          = "HK"
 	CountryCO          = "CO"
 	CountryRO          = "RO"
 	CountryVE          = "VE"
 )
 
-// Common language codes (ISO 639)
+// Common language codes (ISO 639-1); see Languages for the full list
 const (
 	LangEnglish    = "en"
 	LangSpanish    = "es"
@@ -101,46 +104,27 @@ const (
 	LangSwahili    = "sw"
 )
 
-// Valid timespan units
-var validTimespanUnits = map[string]bool{
-	"min": true, "h": true, "hours": true,
-	"d": true, "days": true,
-	"w": true, "weeks": true,
-	"m": true, "months": true,
-}
-
 // DateInput allows flexible date input (string or time.Time)
 type DateInput interface{}
 
 // Near creates a filter for finding words within n words of each other
-// Example: near(5, "airline", "climate") finds "airline" and "climate" within 5 words
-func Near(n int, words ...string) string {
-	if len(words) < 2 {
-		panic("near() requires at least 2 words")
+// Example: Near(5, "airline", "climate") finds "airline" and "climate" within 5 words
+func Near(n int, words ...string) (string, error) {
+	near := query.Near{Distance: n, Words: words}
+	if err := near.Validate(); err != nil {
+		return "", invalid("Near", err)
 	}
-	return fmt.Sprintf("near%d:\"%s\" ", n, strings.Join(words, " "))
+	return query.MustRender(near) + " ", nil
 }
 
 // MultiNear creates multiple near filters combined with AND or OR
-// Example: multiNear([]NearConfig{{5, []string{"airline", "crisis"}}, {10, []string{"airline", "climate", "change"}}}, "AND")
-func MultiNear(configs []NearConfig, method string) string {
-	if method != "AND" && method != "OR" {
-		panic("method must be AND or OR")
-	}
-
-	var formatted []string
-	for _, c := range configs {
-		formatted = append(formatted, Near(c.Distance, c.Words...))
-	}
-
-	if len(formatted) == 1 {
-		return formatted[0]
+// Example: MultiNear([]NearConfig{{5, []string{"airline", "crisis"}}, {10, []string{"airline", "climate", "change"}}}, "AND")
+func MultiNear(configs []NearConfig, method string) (string, error) {
+	nears := make([]query.Expr, len(configs))
+	for i, c := range configs {
+		nears[i] = query.Near{Distance: c.Distance, Words: c.Words}
 	}
-
-	if method == "OR" {
-		return "(" + strings.Join(formatted, " OR ") + ") "
-	}
-	return strings.Join(formatted, " AND ")
+	return combine("Near", nears, method)
 }
 
 // NearConfig configures a near filter
@@ -151,29 +135,43 @@ type NearConfig struct {
 
 // Repeat creates a filter for finding a word repeated at least n times
 // Example: Repeat(3, "environment") finds articles with "environment" at least 3 times
-func Repeat(n int, word string) string {
-	if strings.Contains(word, " ") {
-		panic("repeat() only supports single words")
+func Repeat(n int, word string) (string, error) {
+	repeat := query.Repeat{Count: n, Word: word}
+	if err := repeat.Validate(); err != nil {
+		return "", invalid("Repeat", err)
 	}
-	return fmt.Sprintf("repeat%d:\"%s\" ", n, word)
+	return query.MustRender(repeat) + " ", nil
 }
 
 // MultiRepeat creates multiple repeat filters combined with AND or OR
-// Example: multiRepeat([]RepeatConfig{{2, "airline"}, {3, "airport"}}, "AND")
-func MultiRepeat(configs []RepeatConfig, method string) string {
-	if method != "AND" && method != "OR" {
-		panic("method must be AND or OR")
+// Example: MultiRepeat([]RepeatConfig{{2, "airline"}, {3, "airport"}}, "AND")
+func MultiRepeat(configs []RepeatConfig, method string) (string, error) {
+	repeats := make([]query.Expr, len(configs))
+	for i, c := range configs {
+		repeats[i] = query.Repeat{Count: c.Count, Word: c.Word}
 	}
+	return combine("Repeat", repeats, method)
+}
 
-	var toRepeat []string
-	for _, c := range configs {
-		toRepeat = append(toRepeat, Repeat(c.Count, c.Word))
+// combine validates exprs and renders them joined by method, AND or OR.
+// Errors are reported against field.
+func combine(field string, exprs []query.Expr, method string) (string, error) {
+	if len(exprs) == 0 {
+		return "", &ValidationError{Field: field, Reason: "at least one filter is required"}
+	}
+	for _, e := range exprs {
+		if err := e.Validate(); err != nil {
+			return "", invalid(field, err)
+		}
 	}
 
-	if method == "AND" {
-		return strings.Join(toRepeat, "AND ")
+	switch method {
+	case "AND":
+		return query.MustRender(query.And(exprs)) + " ", nil
+	case "OR":
+		return query.MustRender(query.Or(exprs)) + " ", nil
 	}
-	return "(" + strings.Join(toRepeat, " OR ") + ") "
+	return "", &ValidationError{Field: field, Reason: fmt.Sprintf("method must be AND or OR, got %q", method)}
 }
 
 // RepeatConfig configures a repeat filter
@@ -204,207 +202,362 @@ func ToneAbsFilter(op ToneOp, value string) string {
 	return fmt.Sprintf("toneabs%s%s ", op, value)
 }
 
+// SortOrder controls the order of artlist results
+type SortOrder string
+
+const (
+	SortRelevance SortOrder = ""          // API default, by relevance
+	SortDateDesc  SortOrder = "DateDesc"  // Newest first
+	SortDateAsc   SortOrder = "DateAsc"   // Oldest first
+	SortToneDesc  SortOrder = "ToneDesc"  // Most positive first
+	SortToneAsc   SortOrder = "ToneAsc"   // Most negative first
+	SortHybridRel SortOrder = "HybridRel" // Relevance weighted by source popularity
+)
+
+// IsValid reports whether s is a sort order supported by the API
+func (s SortOrder) IsValid() bool {
+	switch s {
+	case SortRelevance, SortDateDesc, SortDateAsc, SortToneDesc, SortToneAsc, SortHybridRel:
+		return true
+	}
+	return false
+}
+
+// MaxRecords is the most articles or images GDELT returns from a single call
+const MaxRecords = 250
+
+// Timeline smoothing bounds (moving average window, in time steps)
+const (
+	MinTimelineSmooth = 1
+	MaxTimelineSmooth = 30
+)
+
 // Filters holds all filter parameters for GDELT API queries
 type Filters struct {
 	// Date filters - either start/end date OR timespan must be provided
-	StartDate   *time.Time
-	EndDate     *time.Time
-	Timespan    string
-	NumRecords  int
-	Keyword     string
-	KeywordOr   []string // Alternative: multiple keywords OR'd together
-	Domain      string
-	DomainOr    []string // Alternative: multiple domains OR'd together
-	DomainExact string
-	DomainExactOr []string
-	Country     string
-	CountryOr   []string
-	Language    string
-	LanguageOr  []string
-	Theme       string
-	ThemeOr     []string
-	Near        string
-	Repeat      string
-	Tone        string
-	ToneAbs     string
+	StartDate       *time.Time
+	EndDate         *time.Time
+	Timespan        Timespan
+	NumRecords      int
+	Keyword         string
+	KeywordOr       []string // Alternative: multiple keywords OR'd together
+	Domain          string
+	DomainOr        []string // Alternative: multiple domains OR'd together
+	DomainExclude   []string // Domains to exclude (-domain:)
+	DomainExact     string
+	DomainExactOr   []string
+	Country         string
+	CountryOr       []string
+	CountryExclude  []string // Countries to exclude (-sourcecountry:)
+	Language        string
+	LanguageOr      []string
+	LanguageExclude []string // Languages to exclude (-sourcelang:)
+	Theme           string
+	ThemeOr         []string
+	ThemeExclude    []string // Themes to exclude (-theme:)
+	ImageTag        string   // Tag assigned to the image by Google's visual analysis
+	ImageTagOr      []string
+	ImageWebTag     string // Tag derived from captions of the image across the web
+	ImageWebTagOr   []string
+	Near            string
+	Repeat          string
+	Tone            string
+	ToneAbs         string
+	Query           query.Expr // Arbitrary expression ANDed with the other filters
+	Sort            SortOrder  // Order of artlist results (default relevance)
+	TimelineSmooth  int        // Moving average window for timeline modes, 1-30 (0 disables)
 }
 
-// BuildQueryString constructs the query string for the API
+// BuildQueryString constructs the query string for the API. Themes must
+// be in the catalog (see ValidateTheme).
 func (f *Filters) BuildQueryString() (string, error) {
-	var queryParts []string
+	return f.buildQueryString(false)
+}
+
+// buildQueryString is BuildQueryString, only checking the spelling of
+// themes when lenientThemes is set
+func (f *Filters) buildQueryString(lenientThemes bool) (string, error) {
 	var params []string
 
 	// Validate date settings
 	hasDates := f.StartDate != nil || f.EndDate != nil
-	hasTimespan := f.Timespan != ""
+	hasTimespan := !f.Timespan.IsZero()
 
 	if !hasDates && !hasTimespan {
-		return "", errors.New("must provide either StartDate/EndDate or Timespan")
+		return "", &ValidationError{Field: "Timespan", Reason: "must provide either StartDate/EndDate or Timespan"}
 	}
 	if hasDates && hasTimespan {
-		return "", errors.New("cannot provide both StartDate/EndDate and Timespan")
+		return "", &ValidationError{Field: "Timespan", Reason: "cannot provide both StartDate/EndDate and Timespan"}
 	}
 
-	// Add keyword
-	if f.Keyword != "" {
-		queryParts = append(queryParts, fmt.Sprintf("\"%s\"", f.Keyword))
-	} else if len(f.KeywordOr) > 0 {
-		var keywords []string
-		for _, kw := range f.KeywordOr {
-			if strings.Contains(kw, " ") {
-				keywords = append(keywords, fmt.Sprintf("\"%s\"", kw))
-			} else {
-				keywords = append(keywords, kw)
-			}
+	// Catch country, language and theme typos before they silently match
+	// nothing
+	if err := f.validateCodes(lenientThemes); err != nil {
+		return "", err
+	}
+
+	// Compile the content filters into a single expression
+	expr, err := f.Expr()
+	if err != nil {
+		return "", err
+	}
+	queryString, err := query.Render(expr)
+	if err != nil {
+		return "", invalid("Query", err)
+	}
+
+	// Add date filters
+	if hasDates {
+		if f.StartDate == nil {
+			return "", &ValidationError{Field: "StartDate", Reason: "must provide both StartDate and EndDate"}
+		}
+		if f.EndDate == nil {
+			return "", &ValidationError{Field: "EndDate", Reason: "must provide both StartDate and EndDate"}
+		}
+		params = append(params, fmt.Sprintf("startdatetime=%s", formatDate(f.StartDate)))
+		params = append(params, fmt.Sprintf("enddatetime=%s", formatDate(f.EndDate)))
+	} else {
+		if err := f.Timespan.Validate(); err != nil {
+			return "", invalid("Timespan", err)
 		}
-		queryParts = append(queryParts, "("+strings.Join(keywords, " OR ")+") ")
+		params = append(params, fmt.Sprintf("timespan=%s", f.Timespan))
 	}
 
-	// Add domain
-	if f.Domain != "" {
-		queryParts = append(queryParts, fmt.Sprintf("domain:%s ", f.Domain))
-	} else if len(f.DomainOr) > 0 {
-		var domains []string
-		for _, d := range f.DomainOr {
-			domains = append(domains, fmt.Sprintf("domain:%s", d))
+	// Add num_records
+	if f.NumRecords > MaxRecords {
+		return "", &ValidationError{Field: "NumRecords", Reason: fmt.Sprintf("must be %d or less, got %d", MaxRecords, f.NumRecords)}
+	}
+	params = append(params, fmt.Sprintf("maxrecords=%d", f.NumRecords))
+
+	// Add sort order
+	if !f.Sort.IsValid() {
+		return "", &ValidationError{Field: "Sort", Reason: fmt.Sprintf("sort order %s is not supported (must be one of: DateDesc, DateAsc, ToneDesc, ToneAsc, HybridRel)", f.Sort)}
+	}
+	if f.Sort != SortRelevance {
+		params = append(params, fmt.Sprintf("sort=%s", f.Sort))
+	}
+
+	// Add timeline smoothing
+	if f.TimelineSmooth != 0 {
+		if f.TimelineSmooth < MinTimelineSmooth || f.TimelineSmooth > MaxTimelineSmooth {
+			return "", &ValidationError{Field: "TimelineSmooth", Reason: fmt.Sprintf("must be between %d and %d, got %d", MinTimelineSmooth, MaxTimelineSmooth, f.TimelineSmooth)}
 		}
-		queryParts = append(queryParts, "("+strings.Join(domains, " OR ")+") ")
+		params = append(params, fmt.Sprintf("timelinesmooth=%d", f.TimelineSmooth))
 	}
 
-	// Add domain_exact
-	if f.DomainExact != "" {
-		queryParts = append(queryParts, fmt.Sprintf("domainis:%s ", f.DomainExact))
-	} else if len(f.DomainExactOr) > 0 {
-		var domains []string
-		for _, d := range f.DomainExactOr {
-			domains = append(domains, fmt.Sprintf("domainis:%s", d))
+	return queryString + "&" + strings.Join(params, "&"), nil
+}
+
+// validateCodes checks every country, language and theme against the
+// registries, reporting the first unknown code against its field. With
+// lenientThemes, themes outside the catalog only need to be spelled like
+// one; see uncataloguedThemes.
+func (f *Filters) validateCodes(lenientThemes bool) error {
+	validateTheme := ValidateTheme
+	if lenientThemes {
+		validateTheme = checkThemeName
+	}
+	checks := []struct {
+		field    string
+		values   []string
+		validate func(string) error
+	}{
+		{"Country", []string{f.Country}, ValidateCountry},
+		{"CountryOr", f.CountryOr, ValidateCountry},
+		{"CountryExclude", f.CountryExclude, ValidateCountry},
+		{"Language", []string{f.Language}, ValidateLanguage},
+		{"LanguageOr", f.LanguageOr, ValidateLanguage},
+		{"LanguageExclude", f.LanguageExclude, ValidateLanguage},
+		{"Theme", []string{f.Theme}, validateTheme},
+		{"ThemeOr", f.ThemeOr, validateTheme},
+		{"ThemeExclude", f.ThemeExclude, validateTheme},
+	}
+	for _, c := range checks {
+		for _, v := range c.values {
+			if v == "" {
+				continue
+			}
+			if err := c.validate(v); err != nil {
+				return invalid(c.field, err)
+			}
 		}
-		queryParts = append(queryParts, "("+strings.Join(domains, " OR ")+") ")
 	}
+	return nil
+}
 
-	// Add country
-	if f.Country != "" {
-		queryParts = append(queryParts, fmt.Sprintf("sourcecountry:%s ", f.Country))
-	} else if len(f.CountryOr) > 0 {
-		var countries []string
-		for _, c := range f.CountryOr {
-			countries = append(countries, fmt.Sprintf("sourcecountry:%s", c))
+// uncataloguedThemes returns an *UnknownCodeError for every theme that
+// isn't in the bundled catalog, which a client created WithLenientThemes
+// logs before sending the query anyway
+func (f *Filters) uncataloguedThemes() []error {
+	var errs []error
+	for _, theme := range append(append([]string{f.Theme}, f.ThemeOr...), f.ThemeExclude...) {
+		if theme == "" {
+			continue
+		}
+		if err := ValidateTheme(theme); err != nil {
+			errs = append(errs, err)
 		}
-		queryParts = append(queryParts, "("+strings.Join(countries, " OR ")+") ")
 	}
+	return errs
+}
 
-	// Add language
-	if f.Language != "" {
-		queryParts = append(queryParts, fmt.Sprintf("sourcelang:%s ", f.Language))
-	} else if len(f.LanguageOr) > 0 {
-		var langs []string
-		for _, l := range f.LanguageOr {
-			langs = append(langs, fmt.Sprintf("sourcelang:%s", l))
+// Expr compiles the content filters (everything except dates, record
+// count, sort order and smoothing) into a query expression. The parts are
+// ANDed together in a fixed order; nil means no content filter is set.
+func (f *Filters) Expr() (query.Expr, error) {
+	var parts query.And
+	var err error
+
+	// add validates each part as it goes so a bad value is reported
+	// against the field it came from
+	add := func(field string, e query.Expr) {
+		if e == nil || err != nil {
+			return
 		}
-		queryParts = append(queryParts, "("+strings.Join(langs, " OR ")+") ")
+		if verr := e.Validate(); verr != nil {
+			err = invalid(field, verr)
+			return
+		}
+		parts = append(parts, e)
 	}
 
-	// Add theme
-	if f.Theme != "" {
-		queryParts = append(queryParts, fmt.Sprintf("theme:%s ", f.Theme))
-	} else if len(f.ThemeOr) > 0 {
-		var themes []string
-		for _, t := range f.ThemeOr {
-			themes = append(themes, fmt.Sprintf("theme:%s", t))
+	// Keywords: a single keyword is always an exact phrase, while OR'd
+	// keywords are only quoted when they contain spaces
+	if f.Keyword != "" {
+		add("Keyword", query.Phrase(f.Keyword))
+	} else if len(f.KeywordOr) > 0 {
+		var keywords query.Or
+		for _, kw := range f.KeywordOr {
+			if strings.Contains(kw, " ") {
+				keywords = append(keywords, query.Phrase(kw))
+			} else {
+				keywords = append(keywords, query.Term(kw))
+			}
 		}
-		queryParts = append(queryParts, "("+strings.Join(themes, " OR ")+") ")
+		add("KeywordOr", keywords)
 	}
 
-	// Add tone
+	add(orField("Domain", f.Domain), anyOf[query.Domain](f.Domain, f.DomainOr))
+	add(orField("DomainExact", f.DomainExact), anyOf[query.DomainIs](f.DomainExact, f.DomainExactOr))
+	add(orField("Country", f.Country), anyOf[query.SourceCountry](f.Country, f.CountryOr))
+	add(orField("Language", f.Language), anyOf[query.SourceLang](f.Language, f.LanguageOr))
+	add(orField("Theme", f.Theme), anyOf[query.Theme](f.Theme, f.ThemeOr))
+	add(orField("ImageTag", f.ImageTag), anyOf[query.ImageTag](f.ImageTag, f.ImageTagOr))
+	add(orField("ImageWebTag", f.ImageWebTag), anyOf[query.ImageWebTag](f.ImageWebTag, f.ImageWebTagOr))
+
+	// Exclusions, e.g. to drop syndicated or aggregator noise
+	add("DomainExclude", noneOf[query.Domain](f.DomainExclude))
+	add("CountryExclude", noneOf[query.SourceCountry](f.CountryExclude))
+	add("LanguageExclude", noneOf[query.SourceLang](f.LanguageExclude))
+	add("ThemeExclude", noneOf[query.Theme](f.ThemeExclude))
+
 	if f.Tone != "" {
-		if err := validateTone(f.Tone); err != nil {
-			return "", err
+		op, value, terr := parseTone(f.Tone)
+		if terr != nil {
+			return nil, invalid("Tone", terr)
 		}
-		queryParts = append(queryParts, "tone"+f.Tone+" ")
+		add("Tone", query.Tone{Op: op, Value: value})
 	}
 
-	// Add tone absolute
 	if f.ToneAbs != "" {
-		if err := validateTone(f.ToneAbs); err != nil {
-			return "", err
+		op, value, terr := parseTone(f.ToneAbs)
+		if terr != nil {
+			return nil, invalid("ToneAbs", terr)
 		}
-		queryParts = append(queryParts, "toneabs"+f.ToneAbs+" ")
+		add("ToneAbs", query.ToneAbs{Op: op, Value: value})
 	}
 
-	// Add near
+	// Near and Repeat hold fragments already rendered by the helpers
 	if f.Near != "" {
-		queryParts = append(queryParts, f.Near)
+		add("Near", query.Raw(f.Near))
 	}
-
-	// Add repeat
 	if f.Repeat != "" {
-		queryParts = append(queryParts, f.Repeat)
+		add("Repeat", query.Raw(f.Repeat))
 	}
 
-	// Add date filters
-	if f.StartDate != nil {
-		if f.EndDate == nil {
-			return "", errors.New("must provide both StartDate and EndDate")
-		}
-		params = append(params, fmt.Sprintf("startdatetime=%s", formatDate(f.StartDate)))
-		params = append(params, fmt.Sprintf("enddatetime=%s", formatDate(f.EndDate)))
-	} else if f.Timespan != "" {
-		if err := validateTimespan(f.Timespan); err != nil {
-			return "", err
-		}
-		params = append(params, fmt.Sprintf("timespan=%s", f.Timespan))
-	}
+	add("Query", f.Query)
 
-	// Add num_records
-	if f.NumRecords > 250 {
-		return "", fmt.Errorf("num_records must be 250 or less, got %d", f.NumRecords)
+	if err != nil {
+		return nil, err
 	}
-	params = append(params, fmt.Sprintf("maxrecords=%d", f.NumRecords))
+	if len(parts) == 0 {
+		return nil, nil
+	}
+	return parts, nil
+}
 
-	return strings.Join(queryParts, "") + "&" + strings.Join(params, "&"), nil
+// orField names the field an anyOf expression came from: the single value
+// when set, otherwise its Or list
+func orField(field, single string) string {
+	if single != "" {
+		return field
+	}
+	return field + "Or"
 }
 
-// validateTone checks if tone filter is valid
-func validateTone(tone string) error {
-	if !strings.Contains(tone, "<") && !strings.Contains(tone, ">") {
-		return errors.New("tone must contain either greater than (>) or less than (<)")
+// anyOf compiles a single value, or else a list of alternatives, into an
+// expression; it returns nil when neither is set
+func anyOf[T interface {
+	~string
+	query.Expr
+}](single string, alternatives []string) query.Expr {
+	if single != "" {
+		return T(single)
 	}
-	if strings.Contains(tone, "=") && !strings.Contains(tone, ">=") && !strings.Contains(tone, "<=") {
-		return errors.New("tone cannot contain standalone '='")
+	if len(alternatives) == 0 {
+		return nil
 	}
-	return nil
+
+	or := make(query.Or, len(alternatives))
+	for i, v := range alternatives {
+		or[i] = T(v)
+	}
+	return or
 }
 
-// validateTimespan checks if timespan format is valid
-func validateTimespan(timespan string) error {
-	re := regexp.MustCompile(`^(\d+)([a-z]+)$`)
-	matches := re.FindStringSubmatch(timespan)
-	if matches == nil {
-		return fmt.Errorf("timespan %s is invalid", timespan)
+// noneOf compiles a list of excluded values into negated expressions; it
+// returns nil when the list is empty
+func noneOf[T interface {
+	~string
+	query.Expr
+}](excluded []string) query.Expr {
+	if len(excluded) == 0 {
+		return nil
 	}
 
-	value := matches[1]
-	unit := matches[2]
+	and := make(query.And, len(excluded))
+	for i, v := range excluded {
+		and[i] = query.Not{Expr: T(v)}
+	}
+	return and
+}
 
-	if !validTimespanUnits[unit] {
-		return fmt.Errorf("timespan unit %s is not supported (must be one of: min, h, hours, d, days, w, weeks, m, months)", unit)
+// parseTone splits a tone filter such as ">=5" into operator and value
+func parseTone(tone string) (query.Op, float64, error) {
+	if err := validateTone(tone); err != nil {
+		return "", 0, err
 	}
 
-	// Check if value is numeric
-	if _, err := strconv.Atoi(value); err != nil {
-		return fmt.Errorf("timespan value %s is not a valid integer", value)
+	op := query.Op(tone[:1])
+	if strings.HasPrefix(tone[1:], "=") {
+		op = query.Op(tone[:2])
 	}
 
-	minutes, err := strconv.Atoi(value)
+	value, err := strconv.ParseFloat(strings.TrimPrefix(tone, string(op)), 64)
 	if err != nil {
-		return err
+		return "", 0, fmt.Errorf("tone value in %q is not a number", tone)
 	}
+	return op, value, nil
+}
 
-	// Minimum 60 minutes for "min" unit
-	if unit == "min" && minutes < 60 {
-		return fmt.Errorf("timespan must be at least 60 minutes when using 'min' unit")
+// validateTone checks if tone filter is valid
+func validateTone(tone string) error {
+	if !strings.Contains(tone, "<") && !strings.Contains(tone, ">") {
+		return errors.New("tone must contain either greater than (>) or less than (<)")
+	}
+	if strings.Contains(tone, "=") && !strings.Contains(tone, ">=") && !strings.Contains(tone, "<=") {
+		return errors.New("tone cannot contain standalone '='")
 	}
-
 	return nil
 }
 
@@ -0,0 +1,258 @@
+// Package gdelttest records GDELT API traffic to golden files and replays it,
+// so code built on the gdelt client can be tested end to end without the
+// network.
+//
+// A Recorder is an http.RoundTripper. In ModeRecord it forwards requests to
+// the real API and saves each request and response to a JSON file under its
+// directory; in ModeReplay it serves those files and never touches the
+// network:
+//
+//	rec := gdelttest.NewRecorder("testdata/fixtures", gdelttest.ModeFromEnv())
+//	client := gdelt.NewClient(gdelt.WithHTTPClient(&http.Client{Transport: rec}))
+//
+// Run the tests with GDELTTEST_RECORD=1 to refresh the golden files.
+package gdelttest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"unicode/utf8"
+)
+
+// Mode selects whether a Recorder talks to the real API
+type Mode int
+
+const (
+	ModeReplay Mode = iota // Serve golden files; a request without one fails
+	ModeRecord             // Forward to the real API and (over)write golden files
+)
+
+// RecordEnv is the environment variable ModeFromEnv reads
+const RecordEnv = "GDELTTEST_RECORD"
+
+// ModeFromEnv returns ModeRecord when GDELTTEST_RECORD is set to a non-empty
+// value and ModeReplay otherwise
+func ModeFromEnv() Mode {
+	if os.Getenv(RecordEnv) != "" {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+// Scrubbed replaces the value of every scrubbed header in a golden file
+const Scrubbed = "[scrubbed]"
+
+// DefaultScrubHeaders are the headers whose values are never written to
+// golden files
+var DefaultScrubHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Api-Key",
+}
+
+// Recorder is an http.RoundTripper that records to or replays from golden
+// files in Dir
+type Recorder struct {
+	Dir  string
+	Mode Mode
+	// Transport sends requests while recording; nil means
+	// http.DefaultTransport
+	Transport http.RoundTripper
+	// ScrubHeaders lists the request and response headers whose values are
+	// replaced by Scrubbed before saving
+	ScrubHeaders []string
+}
+
+// NewRecorder creates a Recorder for dir that scrubs DefaultScrubHeaders
+func NewRecorder(dir string, mode Mode) *Recorder {
+	return &Recorder{
+		Dir:          dir,
+		Mode:         mode,
+		ScrubHeaders: DefaultScrubHeaders,
+	}
+}
+
+// Golden is the content of a golden file
+type Golden struct {
+	Request  GoldenRequest  `json:"request"`
+	Response GoldenResponse `json:"response"`
+}
+
+// GoldenRequest is the recorded request
+type GoldenRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+}
+
+// GoldenResponse is the recorded response. Bodies that aren't valid UTF-8
+// are stored base64-encoded.
+type GoldenResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+	Base64     bool        `json:"base64,omitempty"`
+}
+
+// MissingGoldenError is returned when replaying a request that was never
+// recorded
+type MissingGoldenError struct {
+	Method string
+	URL    string
+	Path   string // Golden file that was looked for
+}
+
+func (e *MissingGoldenError) Error() string {
+	return fmt.Sprintf("gdelttest: no recording of %s %s at %s; rerun with %s=1 to record it", e.Method, e.URL, e.Path, RecordEnv)
+}
+
+// RoundTrip records or replays req according to r.Mode
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := r.Path(req)
+	if r.Mode == ModeRecord {
+		return r.record(req, path)
+	}
+	return r.replay(req, path)
+}
+
+// Path returns the golden file for req: the API mode followed by a hash of
+// the method and the canonical URL, so the host and the order of the query
+// parameters don't matter
+func (r *Recorder) Path(req *http.Request) string {
+	key := req.Method + " " + canonicalURL(req.URL)
+	sum := sha256.Sum256([]byte(key))
+
+	name := req.URL.Query().Get("mode")
+	if !safeName.MatchString(name) {
+		name = "request"
+	}
+	return filepath.Join(r.Dir, name+"_"+hex.EncodeToString(sum[:6])+".json")
+}
+
+// safeName matches API modes that can be used in file names
+var safeName = regexp.MustCompile(`^[a-z]+$`)
+
+// canonicalURL drops the scheme and host and sorts the query parameters
+func canonicalURL(u *url.URL) string {
+	return u.EscapedPath() + "?" + u.Query().Encode()
+}
+
+func (r *Recorder) record(req *http.Request, path string) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	golden := Golden{
+		Request: GoldenRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: r.scrub(req.Header),
+		},
+		Response: GoldenResponse{
+			StatusCode: resp.StatusCode,
+			Header:     r.scrub(resp.Header),
+		},
+	}
+	if utf8.Valid(body) {
+		golden.Response.Body = string(body)
+	} else {
+		golden.Response.Body = base64.StdEncoding.EncodeToString(body)
+		golden.Response.Base64 = true
+	}
+
+	// Keep URLs readable in diffs of the golden files
+	var data bytes.Buffer
+	enc := json.NewEncoder(&data)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(golden); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(r.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, data.Bytes(), 0o644); err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, path string) (*http.Response, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, &MissingGoldenError{Method: req.Method, URL: req.URL.String(), Path: path}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var golden Golden
+	if err := json.Unmarshal(data, &golden); err != nil {
+		return nil, fmt.Errorf("gdelttest: parsing %s: %w", path, err)
+	}
+	body := []byte(golden.Response.Body)
+	if golden.Response.Base64 {
+		if body, err = base64.StdEncoding.DecodeString(golden.Response.Body); err != nil {
+			return nil, fmt.Errorf("gdelttest: decoding body of %s: %w", path, err)
+		}
+	}
+
+	// Honour cancellation as a real transport would
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+
+	header := golden.Response.Header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", golden.Response.StatusCode, http.StatusText(golden.Response.StatusCode)),
+		StatusCode:    golden.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// scrub returns a copy of h with the values of r.ScrubHeaders replaced
+func (r *Recorder) scrub(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	h = h.Clone()
+	for _, name := range r.ScrubHeaders {
+		if _, ok := h[http.CanonicalHeaderKey(name)]; ok {
+			h.Set(name, Scrubbed)
+		}
+	}
+	return h
+}
@@ -0,0 +1,162 @@
+package gdelttest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// roundTripperFunc stubs the transport a Recorder records from
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func get(t *testing.T, rt http.RoundTripper, rawURL string, header http.Header) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest("GET", rawURL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip(%s) error = %v", rawURL, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(body)
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+	calls := 0
+	upstream := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": []string{"application/json"},
+				"Set-Cookie":   []string{"session=secret"},
+			},
+			Body: io.NopCloser(strings.NewReader(`{"articles": []}`)),
+		}, nil
+	})
+
+	rec := NewRecorder(dir, ModeRecord)
+	rec.Transport = upstream
+	header := http.Header{"Authorization": []string{"Bearer token"}, "Accept": []string{"application/json"}}
+	resp, body := get(t, rec, "https://api.gdeltproject.org/api/v2/doc/doc?query=flood&mode=artlist&format=json", header)
+	if resp.StatusCode != http.StatusOK || body != `{"articles": []}` {
+		t.Fatalf("recorded response = %d %q", resp.StatusCode, body)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "artlist_*.json"))
+	if len(files) != 1 {
+		t.Fatalf("golden files = %v, want one artlist file", files)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"Bearer token", "session=secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("golden file leaks %q:\n%s", secret, data)
+		}
+	}
+	if !strings.Contains(string(data), Scrubbed) || !strings.Contains(string(data), "application/json") {
+		t.Errorf("golden file should keep header names and unscrubbed values:\n%s", data)
+	}
+
+	// Replay matches regardless of host and parameter order, without the
+	// network
+	replay := NewRecorder(dir, ModeReplay)
+	replay.Transport = roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		t.Fatal("replay must not use the transport")
+		return nil, nil
+	})
+	resp, body = get(t, replay, "http://localhost/api/v2/doc/doc?format=json&mode=artlist&query=flood", nil)
+	if resp.StatusCode != http.StatusOK || body != `{"articles": []}` {
+		t.Errorf("replayed response = %d %q", resp.StatusCode, body)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("replayed Content-Type = %q", ct)
+	}
+	if calls != 1 {
+		t.Errorf("upstream called %d times, want 1", calls)
+	}
+}
+
+func TestReplayBinaryAndErrorResponses(t *testing.T) {
+	dir := t.TempDir()
+	rec := NewRecorder(dir, ModeRecord)
+	rec.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("mode") == "imagecollage" {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("\xff\xd8\xff\xe0"))}, nil
+		}
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Body: io.NopCloser(strings.NewReader("slow down"))}, nil
+	})
+	get(t, rec, "https://example.com/api?mode=imagecollage", nil)
+	get(t, rec, "https://example.com/api?mode=artlist", nil)
+
+	replay := NewRecorder(dir, ModeReplay)
+	if _, body := get(t, replay, "https://example.com/api?mode=imagecollage", nil); body != "\xff\xd8\xff\xe0" {
+		t.Errorf("binary body = %q", body)
+	}
+	if resp, body := get(t, replay, "https://example.com/api?mode=artlist", nil); resp.StatusCode != http.StatusTooManyRequests || body != "slow down" {
+		t.Errorf("error response = %d %q", resp.StatusCode, body)
+	}
+}
+
+func TestReplayMissingGolden(t *testing.T) {
+	rec := NewRecorder(t.TempDir(), ModeReplay)
+	req, _ := http.NewRequest("GET", "https://example.com/api?mode=timelinevol&query=x", nil)
+	_, err := rec.RoundTrip(req)
+
+	var missing *MissingGoldenError
+	if !errors.As(err, &missing) {
+		t.Fatalf("RoundTrip() error = %v, want MissingGoldenError", err)
+	}
+	if missing.Path != rec.Path(req) || !strings.Contains(err.Error(), RecordEnv) {
+		t.Errorf("error = %v", err)
+	}
+}
+
+func TestPath(t *testing.T) {
+	rec := NewRecorder("fixtures", ModeReplay)
+	path := func(rawURL string) string {
+		req, _ := http.NewRequest("GET", rawURL, nil)
+		return rec.Path(req)
+	}
+
+	if a, b := path("https://a.example/api?query=x&mode=artlist"), path("http://b.example/api?mode=artlist&query=x"); a != b {
+		t.Errorf("host or parameter order changed the path: %s != %s", a, b)
+	}
+	if a, b := path("https://a.example/api?mode=artlist&query=x"), path("https://a.example/api?mode=artlist&query=y"); a == b {
+		t.Errorf("different queries share %s", a)
+	}
+	if p := path("https://a.example/api?mode=../../etc"); !strings.HasPrefix(filepath.Base(p), "request_") || filepath.Dir(p) != "fixtures" {
+		t.Errorf("unsafe mode produced path %s", p)
+	}
+}
+
+func TestModeFromEnv(t *testing.T) {
+	t.Setenv(RecordEnv, "")
+	if ModeFromEnv() != ModeReplay {
+		t.Error("ModeFromEnv() should replay by default")
+	}
+	t.Setenv(RecordEnv, "1")
+	if ModeFromEnv() != ModeRecord {
+		t.Error("ModeFromEnv() should record when GDELTTEST_RECORD is set")
+	}
+}
@@ -0,0 +1,173 @@
+// Command genregistry generates the country, language and theme registries
+// of the gdelt package from the tab-separated tables in gdelt/data.
+//
+// Usage (from the gdelt package, via go generate):
+//
+//	go run ./internal/cmd/genregistry -countries data/countries.tsv -languages data/languages.tsv -themes data/themes.tsv -o registry_gen.go
+//
+// -gkgthemes adds every theme of GDELT's GKG theme lookup
+// (http://data.gdeltproject.org/api/v2/guides/LOOKUP-GKGTHEMES.TXT, one
+// theme and article count per line) to the theme table, which then only
+// supplies descriptions and families.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+func main() {
+	countriesPath := flag.String("countries", "data/countries.tsv", "country table")
+	languagesPath := flag.String("languages", "data/languages.tsv", "language table")
+	themesPath := flag.String("themes", "data/themes.tsv", "theme table")
+	gkgThemesPath := flag.String("gkgthemes", "", "GKG theme lookup (LOOKUP-GKGTHEMES.TXT) to import, if any")
+	out := flag.String("o", "registry_gen.go", "output file")
+	flag.Parse()
+
+	countries, err := readTable(*countriesPath, 3)
+	if err != nil {
+		log.Fatal(err)
+	}
+	languages, err := readTable(*languagesPath, 3)
+	if err != nil {
+		log.Fatal(err)
+	}
+	themes, err := readTable(*themesPath, 2)
+	if err != nil {
+		log.Fatal(err)
+	}
+	themeSources := *themesPath
+	if *gkgThemesPath != "" {
+		gkgThemes, err := readLookup(*gkgThemesPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		themes = mergeThemes(themes, gkgThemes)
+		themeSources += " (with " + *gkgThemesPath + ")"
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by genregistry from %s, %s and %s. DO NOT EDIT.\n\n", *countriesPath, *languagesPath, themeSources)
+	b.WriteString("package gdelt\n\n")
+
+	b.WriteString("// countries lists every FIPS 10-4 code GDELT accepts\n")
+	b.WriteString("var countries = []Country{\n")
+	for _, row := range countries {
+		fmt.Fprintf(&b, "\t{FIPS: %q, ISO: %q, Name: %q%s},\n", row[0], row[1], row[2], aliases(row))
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("// languages lists every language GDELT monitors\n")
+	b.WriteString("var languages = []Language{\n")
+	for _, row := range languages {
+		fmt.Fprintf(&b, "\t{Code: %q, ISO3: %q, Name: %q%s},\n", row[0], row[1], row[2], aliases(row))
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("// themes lists the GKG themes and theme families GDELT understands\n")
+	b.WriteString("var themes = []Theme{\n")
+	for _, row := range themes {
+		fmt.Fprintf(&b, "\t{Name: %q, Description: %q},\n", row[0], row[1])
+	}
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v", err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readTable reads a tab-separated table, skipping comments and the header
+// row. Every row has at least minCols columns; an extra last column holds
+// aliases separated by "|".
+func readTable(path string, minCols int) ([][]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var rows [][]string
+	header := true
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if header {
+			header = false
+			continue
+		}
+		row := strings.Split(text, "\t")
+		if len(row) < minCols || len(row) > minCols+1 || row[0] == "" || row[minCols-1] == "" {
+			return nil, fmt.Errorf("%s:%d: malformed row %q", path, line, text)
+		}
+		rows = append(rows, row)
+	}
+	return rows, scanner.Err()
+}
+
+// readLookup reads the theme names of a GKG theme lookup, ignoring the
+// article counts that follow them
+func readLookup(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		name, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), "\t")
+		if name == "" {
+			continue
+		}
+		if strings.ToUpper(name) != name || strings.ContainsAny(name, " *") {
+			return nil, fmt.Errorf("%s:%d: malformed theme %q", path, line, name)
+		}
+		names = append(names, name)
+	}
+	return names, scanner.Err()
+}
+
+// mergeThemes adds the themes of a GKG lookup missing from the theme
+// table, without descriptions, and sorts the result by name
+func mergeThemes(table [][]string, lookup []string) [][]string {
+	seen := make(map[string]bool, len(table))
+	for _, row := range table {
+		seen[row[0]] = true
+	}
+	for _, name := range lookup {
+		if !seen[name] {
+			seen[name] = true
+			table = append(table, []string{name, ""})
+		}
+	}
+	sort.Slice(table, func(i, j int) bool { return table[i][0] < table[j][0] })
+	return table
+}
+
+// aliases renders the optional aliases column of a country or language as
+// a struct field
+func aliases(row []string) string {
+	if len(row) < 4 || row[3] == "" {
+		return ""
+	}
+	var quoted []string
+	for _, alias := range strings.Split(row[3], "|") {
+		quoted = append(quoted, fmt.Sprintf("%q", alias))
+	}
+	return fmt.Sprintf(", Aliases: []string{%s}", strings.Join(quoted, ", "))
+}
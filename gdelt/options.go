@@ -0,0 +1,80 @@
+package gdelt
+
+import (
+	"log"
+	"net/http"
+	"strings"
+)
+
+// Option configures a Client created by NewClient
+type Option func(*Client)
+
+// WithBaseURL points the client at a different DOC API endpoint, such as a
+// mirror, a recording proxy or a local fake
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "?")
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithJSONParseDepth sets the deepest nesting the JSON repairer accepts
+func WithJSONParseDepth(depth int) Option {
+	return func(c *Client) {
+		c.jsonParseDepth = depth
+	}
+}
+
+// WithLogger sets a logger for retries and other diagnostics.
+// By default the client does not log.
+func WithLogger(logger *log.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry transient API errors
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithRateLimiter sets the limiter every request waits on; nil disables
+// client-side rate limiting
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// WithLenientThemes lets queries use themes missing from the bundled
+// catalog, as long as they are spelled like a theme. Each one is logged
+// before the query is sent. By default such themes are rejected with an
+// *UnknownCodeError.
+func WithLenientThemes() Option {
+	return func(c *Client) {
+		c.lenientThemes = true
+	}
+}
+
+// WithCache sets the response cache and its TTLs
+func WithCache(cache Cache, ttl CacheTTL) Option {
+	return func(c *Client) {
+		c.cache = cache
+		c.cacheTTL = ttl
+	}
+}
@@ -0,0 +1,110 @@
+package gdelt
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sort"
+	"time"
+)
+
+// UpdateInterval is how often GDELT publishes new articles; seen dates are
+// always aligned to it, so windows are never split below this size
+const UpdateInterval = 15 * time.Minute
+
+// ArticleSearchAll returns an iterator over every article matching filters
+// between StartDate and EndDate, lifting the per-call MaxRecords cap.
+//
+// A window whose page comes back saturated is split in half and each half
+// is fetched in turn, recursively, until every page fits. Articles are
+// yielded in ascending seen-date order and deduplicated by URL; NumRecords
+// and Sort are ignored. Each call goes through the client's rate limiter,
+// and iteration stops with ctx's error once ctx is done. A window of a
+// single update interval that is still saturated cannot be split further,
+// so its first MaxRecords articles are yielded and the rest are dropped.
+func (c *Client) ArticleSearchAll(ctx context.Context, filters *Filters) iter.Seq2[Article, error] {
+	return func(yield func(Article, error) bool) {
+		if filters.StartDate == nil || filters.EndDate == nil || !filters.Timespan.IsZero() {
+			yield(Article{}, errors.New("paginated search requires StartDate and EndDate"))
+			return
+		}
+		if !filters.StartDate.Before(*filters.EndDate) {
+			yield(Article{}, errors.New("StartDate must be before EndDate"))
+			return
+		}
+
+		p := &paginator{
+			client: c,
+			ctx:    ctx,
+			base:   *filters,
+			seen:   make(map[string]struct{}),
+			yield:  yield,
+		}
+		p.window(*filters.StartDate, *filters.EndDate)
+	}
+}
+
+// paginator holds the state of a single ArticleSearchAll iteration
+type paginator struct {
+	client *Client
+	ctx    context.Context
+	base   Filters
+	seen   map[string]struct{}
+	yield  func(Article, error) bool
+}
+
+// window fetches [start, end], splitting it when the page is saturated.
+// It reports whether iteration should continue.
+func (p *paginator) window(start, end time.Time) bool {
+	if err := p.ctx.Err(); err != nil {
+		p.yield(Article{}, err)
+		return false
+	}
+
+	f := p.base
+	f.StartDate = &start
+	f.EndDate = &end
+	f.NumRecords = MaxRecords
+	f.Sort = SortDateAsc
+
+	articles, err := p.client.ArticleSearchContext(p.ctx, &f)
+	if err != nil {
+		p.yield(Article{}, err)
+		return false
+	}
+
+	if len(articles) >= MaxRecords {
+		if mid, ok := splitWindow(start, end); ok {
+			return p.window(start, mid) && p.window(mid, end)
+		}
+		p.client.logf("window %s to %s still has more than %d articles; results are truncated",
+			formatDate(&start), formatDate(&end), MaxRecords)
+	}
+
+	sort.SliceStable(articles, func(i, j int) bool {
+		return articles[i].SeenDate < articles[j].SeenDate
+	})
+	for _, article := range articles {
+		if _, dup := p.seen[article.URL]; dup {
+			continue
+		}
+		p.seen[article.URL] = struct{}{}
+		if !p.yield(article, nil) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitWindow picks a split point for [start, end] on an update interval
+// boundary, or reports false when the window cannot be split further
+func splitWindow(start, end time.Time) (time.Time, bool) {
+	mid := start.Add(end.Sub(start) / 2).Truncate(UpdateInterval)
+	if !mid.After(start) {
+		mid = start.Truncate(UpdateInterval).Add(UpdateInterval)
+	}
+	if !mid.Before(end) {
+		return time.Time{}, false
+	}
+	return mid, true
+}
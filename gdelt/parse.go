@@ -0,0 +1,294 @@
+package gdelt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tri2820/gdelt/query"
+)
+
+// ParseQuery parses a raw GDELT query, as pasted from the GDELT web UI or
+// produced by BuildQueryString, back into Filters. URL parameters after the
+// first '&' (timespan, startdatetime, enddatetime, maxrecords, sort,
+// timelinesmooth) are optional.
+//
+// Top-level operands are lifted into the matching Filters fields in the
+// order BuildQueryString emits them; anything that does not fit (nested
+// boolean groups, bare terms, operators out of order) is kept in
+// Filters.Query. Building the returned filters reproduces the query.
+// Malformed input yields a *query.SyntaxError with the byte offset.
+func ParseQuery(input string) (*Filters, error) {
+	exprText, params, hasParams := cutOutsideQuotes(input, '&')
+
+	expr, err := query.Parse(exprText)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Filters{}
+	liftExpr(f, expr)
+
+	if hasParams {
+		if err := parseParams(f, params, len(exprText)+1); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// cutOutsideQuotes splits s at the first sep that is not inside quotes
+func cutOutsideQuotes(s string, sep byte) (string, string, bool) {
+	inQuotes := false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case sep:
+			if !inQuotes {
+				return s[:i], s[i+1:], true
+			}
+		}
+	}
+	return s, "", false
+}
+
+// Stages in the order BuildQueryString renders the content filters
+const (
+	stageKeyword = iota
+	stageDomain
+	stageDomainExact
+	stageCountry
+	stageLanguage
+	stageTheme
+	stageImageTag
+	stageImageWebTag
+	stageDomainExclude
+	stageCountryExclude
+	stageLanguageExclude
+	stageThemeExclude
+	stageTone
+	stageToneAbs
+	stageNear
+	stageRepeat
+	stageQuery
+)
+
+// liftExpr assigns the top-level operands of expr to Filters fields
+func liftExpr(f *Filters, expr query.Expr) {
+	operands, ok := expr.(query.And)
+	if !ok {
+		operands = query.And{expr}
+	}
+
+	var rest query.And
+	stage := stageKeyword - 1
+	for _, e := range operands {
+		next := stageQuery
+		if stage < stageQuery {
+			next = liftOperand(f, e, stage)
+		}
+		if next == stageQuery {
+			rest = append(rest, e)
+		}
+		stage = next
+	}
+
+	switch len(rest) {
+	case 0:
+	case 1:
+		f.Query = rest[0]
+	default:
+		f.Query = rest
+	}
+}
+
+// liftOperand tries to store e in a field after stage, returning the stage
+// it was stored at, or stageQuery if it must stay an expression
+func liftOperand(f *Filters, e query.Expr, stage int) int {
+	// assign stores e at target if the stage ordering allows it. Only list
+	// fields that render one operand per value may be assigned repeatedly.
+	assign := func(target int, set func()) int {
+		if target < stage || (target == stage && !repeatableStage(target)) {
+			return stageQuery
+		}
+		set()
+		return target
+	}
+
+	switch v := e.(type) {
+	case query.Phrase:
+		return assign(stageKeyword, func() { f.Keyword = string(v) })
+	case query.Domain:
+		return assign(stageDomain, func() { f.Domain = string(v) })
+	case query.DomainIs:
+		return assign(stageDomainExact, func() { f.DomainExact = string(v) })
+	case query.SourceCountry:
+		return assign(stageCountry, func() { f.Country = string(v) })
+	case query.SourceLang:
+		return assign(stageLanguage, func() { f.Language = string(v) })
+	case query.Theme:
+		return assign(stageTheme, func() { f.Theme = string(v) })
+	case query.ImageTag:
+		return assign(stageImageTag, func() { f.ImageTag = string(v) })
+	case query.ImageWebTag:
+		return assign(stageImageWebTag, func() { f.ImageWebTag = string(v) })
+	case query.Tone:
+		return assign(stageTone, func() { f.Tone = string(v.Op) + strconv.FormatFloat(v.Value, 'f', -1, 64) })
+	case query.ToneAbs:
+		return assign(stageToneAbs, func() { f.ToneAbs = string(v.Op) + strconv.FormatFloat(v.Value, 'f', -1, 64) })
+	case query.Near:
+		return assign(stageNear, func() { f.Near += query.MustRender(v) + " " })
+	case query.Repeat:
+		return assign(stageRepeat, func() { f.Repeat += query.MustRender(v) + " " })
+	case query.Not:
+		switch n := v.Expr.(type) {
+		case query.Domain:
+			return assign(stageDomainExclude, func() { f.DomainExclude = append(f.DomainExclude, string(n)) })
+		case query.SourceCountry:
+			return assign(stageCountryExclude, func() { f.CountryExclude = append(f.CountryExclude, string(n)) })
+		case query.SourceLang:
+			return assign(stageLanguageExclude, func() { f.LanguageExclude = append(f.LanguageExclude, string(n)) })
+		case query.Theme:
+			return assign(stageThemeExclude, func() { f.ThemeExclude = append(f.ThemeExclude, string(n)) })
+		}
+	case query.Or:
+		return liftOr(f, v, assign)
+	}
+	return stageQuery
+}
+
+// repeatableStage reports whether consecutive operands may share a stage
+func repeatableStage(stage int) bool {
+	switch stage {
+	case stageDomainExclude, stageCountryExclude, stageLanguageExclude, stageThemeExclude, stageNear, stageRepeat:
+		return true
+	}
+	return false
+}
+
+// liftOr stores OR groups whose alternatives are all of one kind
+func liftOr(f *Filters, or query.Or, assign func(int, func()) int) int {
+	if values, ok := orValues[query.Domain](or); ok {
+		return assign(stageDomain, func() { f.DomainOr = values })
+	}
+	if values, ok := orValues[query.DomainIs](or); ok {
+		return assign(stageDomainExact, func() { f.DomainExactOr = values })
+	}
+	if values, ok := orValues[query.SourceCountry](or); ok {
+		return assign(stageCountry, func() { f.CountryOr = values })
+	}
+	if values, ok := orValues[query.SourceLang](or); ok {
+		return assign(stageLanguage, func() { f.LanguageOr = values })
+	}
+	if values, ok := orValues[query.Theme](or); ok {
+		return assign(stageTheme, func() { f.ThemeOr = values })
+	}
+	if values, ok := orValues[query.ImageTag](or); ok {
+		return assign(stageImageTag, func() { f.ImageTagOr = values })
+	}
+	if values, ok := orValues[query.ImageWebTag](or); ok {
+		return assign(stageImageWebTag, func() { f.ImageWebTagOr = values })
+	}
+	if keywords, ok := orKeywords(or); ok {
+		return assign(stageKeyword, func() { f.KeywordOr = keywords })
+	}
+	if allOf[query.Near](or) {
+		return assign(stageNear, func() { f.Near += query.MustRender(or) + " " })
+	}
+	if allOf[query.Repeat](or) {
+		return assign(stageRepeat, func() { f.Repeat += query.MustRender(or) + " " })
+	}
+	return stageQuery
+}
+
+// orValues returns the values of an OR group made only of T operators
+func orValues[T ~string](or query.Or) ([]string, bool) {
+	values := make([]string, len(or))
+	for i, e := range or {
+		v, ok := e.(T)
+		if !ok {
+			return nil, false
+		}
+		values[i] = string(v)
+	}
+	return values, true
+}
+
+// orKeywords returns the keywords of an OR group of terms and phrases,
+// provided BuildQueryString would render them identically
+func orKeywords(or query.Or) ([]string, bool) {
+	keywords := make([]string, len(or))
+	for i, e := range or {
+		switch v := e.(type) {
+		case query.Term:
+			keywords[i] = string(v)
+		case query.Phrase:
+			if !strings.Contains(string(v), " ") {
+				return nil, false
+			}
+			keywords[i] = string(v)
+		default:
+			return nil, false
+		}
+	}
+	return keywords, true
+}
+
+// allOf reports whether every operand of the group is a T
+func allOf[T query.Expr](or query.Or) bool {
+	for _, e := range or {
+		if _, ok := e.(T); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// parseParams parses the URL parameters of a query string; offset is the
+// position of params within the original input, for error reporting
+func parseParams(f *Filters, params string, offset int) error {
+	for _, param := range strings.Split(params, "&") {
+		name, value, _ := strings.Cut(param, "=")
+		valueOffset := offset + len(name) + 1
+
+		switch name {
+		case "timespan":
+			timespan, err := ParseTimespan(value)
+			if err != nil {
+				return &query.SyntaxError{Offset: valueOffset, Msg: err.Error()}
+			}
+			f.Timespan = timespan
+		case "startdatetime", "enddatetime":
+			t, err := time.Parse("20060102150405", value)
+			if err != nil {
+				return &query.SyntaxError{Offset: valueOffset, Msg: fmt.Sprintf("%s must be YYYYMMDDHHMMSS, got %q", name, value)}
+			}
+			if name == "startdatetime" {
+				f.StartDate = &t
+			} else {
+				f.EndDate = &t
+			}
+		case "maxrecords":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return &query.SyntaxError{Offset: valueOffset, Msg: fmt.Sprintf("maxrecords must be an integer, got %q", value)}
+			}
+			f.NumRecords = n
+		case "sort":
+			f.Sort = SortOrder(value)
+		case "timelinesmooth":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return &query.SyntaxError{Offset: valueOffset, Msg: fmt.Sprintf("timelinesmooth must be an integer, got %q", value)}
+			}
+			f.TimelineSmooth = n
+		default:
+			return &query.SyntaxError{Offset: offset, Msg: fmt.Sprintf("unknown parameter %q", name)}
+		}
+
+		offset += len(param) + 1
+	}
+	return nil
+}
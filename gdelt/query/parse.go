@@ -0,0 +1,320 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SyntaxError reports a malformed query and where it went wrong
+type SyntaxError struct {
+	Offset int    // Byte offset into the input
+	Msg    string // Description of the problem
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at offset %d: %s", e.Offset, e.Msg)
+}
+
+// Parse parses a GDELT query expression, such as one copied from the GDELT
+// web UI, into an expression tree. Whitespace between operands means AND,
+// "OR" alternatives bind looser than AND, and a leading "-" negates.
+// Operators the tree does not model (e.g. imageocrmeta:) are kept as Raw.
+func Parse(input string) (Expr, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return And{}, nil
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, &SyntaxError{Offset: tok.offset, Msg: fmt.Sprintf("unexpected %s", tok)}
+	}
+	return expr, nil
+}
+
+// tokenKind classifies lexer tokens
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokLParen
+	tokRParen
+	tokNot
+	tokAnd
+	tokOr
+	tokPhrase
+	tokWord
+)
+
+// token is a lexeme with its position in the input
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of query"
+	case tokLParen:
+		return "'('"
+	case tokRParen:
+		return "')'"
+	case tokNot:
+		return "'-'"
+	}
+	return strconv.Quote(t.text)
+}
+
+// lex splits the input into tokens
+func lex(input string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(input) {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", offset: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", offset: i})
+			i++
+		case c == '-':
+			if i+1 >= len(input) || strings.ContainsRune(" \t\n\r)", rune(input[i+1])) {
+				return nil, &SyntaxError{Offset: i, Msg: "'-' must be followed by an operand"}
+			}
+			tokens = append(tokens, token{kind: tokNot, text: "-", offset: i})
+			i++
+		case c == '"':
+			end := strings.IndexByte(input[i+1:], '"')
+			if end < 0 {
+				return nil, &SyntaxError{Offset: i, Msg: "unterminated phrase"}
+			}
+			tokens = append(tokens, token{kind: tokPhrase, text: input[i+1 : i+1+end], offset: i})
+			i += end + 2
+		default:
+			start := i
+			for i < len(input) && !strings.ContainsRune(" \t\n\r()", rune(input[i])) {
+				if input[i] == '"' {
+					// Quoted operator value, e.g. near5:"a b" or imagetag:"x"
+					if input[i-1] != ':' {
+						return nil, &SyntaxError{Offset: i, Msg: "unexpected quote inside word"}
+					}
+					end := strings.IndexByte(input[i+1:], '"')
+					if end < 0 {
+						return nil, &SyntaxError{Offset: i, Msg: "unterminated quoted value"}
+					}
+					i += end + 2
+					break
+				}
+				i++
+			}
+			text := input[start:i]
+			kind := tokWord
+			switch text {
+			case "AND":
+				kind = tokAnd
+			case "OR":
+				kind = tokOr
+			}
+			tokens = append(tokens, token{kind: kind, text: text, offset: start})
+		}
+	}
+	return append(tokens, token{kind: tokEOF, offset: len(input)}), nil
+}
+
+// parser is a recursive descent parser over the token stream
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// parseOr parses alternatives separated by OR
+func (p *parser) parseOr() (Expr, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	or := Or{first}
+	for p.peek().kind == tokOr {
+		p.next()
+		alt, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, alt)
+	}
+
+	if len(or) == 1 {
+		return first, nil
+	}
+	return or, nil
+}
+
+// parseAnd parses operands joined by whitespace or an explicit AND
+func (p *parser) parseAnd() (Expr, error) {
+	var and And
+	for {
+		tok := p.peek()
+		switch tok.kind {
+		case tokEOF, tokRParen, tokOr:
+			if len(and) == 0 {
+				return nil, &SyntaxError{Offset: tok.offset, Msg: fmt.Sprintf("expected an operand, found %s", tok)}
+			}
+			if len(and) == 1 {
+				return and[0], nil
+			}
+			return and, nil
+		case tokAnd:
+			if len(and) == 0 {
+				return nil, &SyntaxError{Offset: tok.offset, Msg: "AND must follow an operand"}
+			}
+			p.next()
+			if next := p.peek(); next.kind == tokEOF || next.kind == tokRParen || next.kind == tokOr || next.kind == tokAnd {
+				return nil, &SyntaxError{Offset: next.offset, Msg: fmt.Sprintf("expected an operand after AND, found %s", next)}
+			}
+		}
+
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, expr)
+	}
+}
+
+// parseUnary parses an optionally negated operand
+func (p *parser) parseUnary() (Expr, error) {
+	if tok := p.peek(); tok.kind == tokNot {
+		p.next()
+		operand, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		not := Not{Expr: operand}
+		if err := not.Validate(); err != nil {
+			return nil, &SyntaxError{Offset: tok.offset, Msg: err.Error()}
+		}
+		return not, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses a parenthesised group or a single operator
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokLParen:
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, &SyntaxError{Offset: tok.offset, Msg: "missing closing parenthesis"}
+		}
+		return expr, nil
+	case tokPhrase:
+		return validated(Phrase(tok.text), tok)
+	case tokWord:
+		return parseWord(tok)
+	}
+	return nil, &SyntaxError{Offset: tok.offset, Msg: fmt.Sprintf("expected an operand, found %s", tok)}
+}
+
+var (
+	nearPattern   = regexp.MustCompile(`^near(\d+):"([^"]*)"$`)
+	repeatPattern = regexp.MustCompile(`^repeat(\d+):"([^"]*)"$`)
+	tonePattern   = regexp.MustCompile(`^(toneabs|tone)(>=|<=|>|<)(.*)$`)
+	fieldPattern  = regexp.MustCompile(`^([a-z]+):(.*)$`)
+)
+
+// parseWord classifies a bare word or name:value operator
+func parseWord(tok token) (Expr, error) {
+	text := tok.text
+
+	if m := nearPattern.FindStringSubmatch(text); m != nil {
+		distance, _ := strconv.Atoi(m[1])
+		return validated(Near{Distance: distance, Words: strings.Fields(m[2])}, tok)
+	}
+	if strings.HasPrefix(text, "near") && strings.Contains(text, ":") {
+		return nil, &SyntaxError{Offset: tok.offset, Msg: fmt.Sprintf("malformed near operator %q (want nearN:\"word word\")", text)}
+	}
+
+	if m := repeatPattern.FindStringSubmatch(text); m != nil {
+		count, _ := strconv.Atoi(m[1])
+		return validated(Repeat{Count: count, Word: m[2]}, tok)
+	}
+	if strings.HasPrefix(text, "repeat") && strings.Contains(text, ":") {
+		return nil, &SyntaxError{Offset: tok.offset, Msg: fmt.Sprintf("malformed repeat operator %q (want repeatN:\"word\")", text)}
+	}
+
+	if m := tonePattern.FindStringSubmatch(text); m != nil {
+		value, err := strconv.ParseFloat(m[3], 64)
+		if err != nil {
+			return nil, &SyntaxError{Offset: tok.offset + len(m[1]) + len(m[2]), Msg: fmt.Sprintf("%s value %q is not a number", m[1], m[3])}
+		}
+		if m[1] == "toneabs" {
+			return ToneAbs{Op: Op(m[2]), Value: value}, nil
+		}
+		return Tone{Op: Op(m[2]), Value: value}, nil
+	}
+
+	if m := fieldPattern.FindStringSubmatch(text); m != nil {
+		name, value := m[1], m[2]
+		if value == "" {
+			return nil, &SyntaxError{Offset: tok.offset + len(name) + 1, Msg: fmt.Sprintf("missing value for %s:", name)}
+		}
+		unquoted := strings.Trim(value, "\"")
+		switch name {
+		case "domain":
+			return validated(Domain(unquoted), tok)
+		case "domainis":
+			return validated(DomainIs(unquoted), tok)
+		case "sourcecountry":
+			return validated(SourceCountry(unquoted), tok)
+		case "sourcelang":
+			return validated(SourceLang(unquoted), tok)
+		case "theme":
+			return validated(Theme(unquoted), tok)
+		case "imagetag":
+			return validated(ImageTag(unquoted), tok)
+		case "imagewebtag":
+			return validated(ImageWebTag(unquoted), tok)
+		}
+		return Raw(text), nil
+	}
+
+	return validated(Term(text), tok)
+}
+
+// validated attaches the token position to validation errors
+func validated(expr Expr, tok token) (Expr, error) {
+	if err := expr.Validate(); err != nil {
+		return nil, &SyntaxError{Offset: tok.offset, Msg: err.Error()}
+	}
+	return expr, nil
+}
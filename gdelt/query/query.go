@@ -0,0 +1,399 @@
+// Package query provides a typed expression tree for GDELT DOC API queries.
+//
+// Expressions are built by composing the node types below and rendered to
+// the API's query syntax with Render:
+//
+//	expr := query.Or{
+//		query.And{query.Phrase("interest rates"), query.Domain("reuters.com")},
+//		query.And{query.Term("inflation"), query.Not{Expr: query.SourceLang("en")}},
+//	}
+//	s, err := query.Render(expr)
+//	// (("interest rates" AND domain:reuters.com) OR (inflation AND -sourcelang:en))
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Expr is a node of a query expression
+type Expr interface {
+	// Validate reports whether the node and its children can be rendered
+	Validate() error
+	render(b *strings.Builder, pos position)
+}
+
+// position is where a node is rendered, which decides whether it needs
+// parentheses
+type position int
+
+const (
+	posTop position = iota // top level, or a direct child of And at top level
+	posOr                  // child of an Or group
+	posNot                 // operand of Not
+)
+
+// Render validates expr and renders it in GDELT query syntax
+func Render(expr Expr) (string, error) {
+	if expr == nil {
+		return "", nil
+	}
+	if err := expr.Validate(); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	expr.render(&b, posTop)
+	return b.String(), nil
+}
+
+// MustRender is like Render but panics if expr is invalid
+func MustRender(expr Expr) string {
+	s, err := Render(expr)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Term is a single keyword, matched anywhere in the article
+type Term string
+
+func (t Term) Validate() error {
+	if t == "" {
+		return errors.New("term must not be empty")
+	}
+	if strings.ContainsAny(string(t), " \t\n\"") {
+		return fmt.Errorf("term %q must be a single word (use Phrase for multiple words)", string(t))
+	}
+	// Anything the parser would read as syntax can't be a term
+	if t == "AND" || t == "OR" {
+		return fmt.Errorf("term %q is a reserved word (use Phrase to search for it)", string(t))
+	}
+	if strings.HasPrefix(string(t), "-") {
+		return fmt.Errorf("term %q must not start with \"-\" (use Not to exclude it)", string(t))
+	}
+	if strings.ContainsAny(string(t), "()") {
+		return fmt.Errorf("term %q must not contain parentheses", string(t))
+	}
+	return nil
+}
+
+func (t Term) render(b *strings.Builder, _ position) {
+	b.WriteString(string(t))
+}
+
+// Phrase is an exact phrase, rendered in quotes
+type Phrase string
+
+func (p Phrase) Validate() error {
+	if strings.TrimSpace(string(p)) == "" {
+		return errors.New("phrase must not be empty")
+	}
+	if strings.Contains(string(p), "\"") {
+		return fmt.Errorf("phrase %q must not contain quotes", string(p))
+	}
+	return nil
+}
+
+func (p Phrase) render(b *strings.Builder, _ position) {
+	b.WriteString("\"" + string(p) + "\"")
+}
+
+// And matches articles matching all of its operands
+type And []Expr
+
+func (a And) Validate() error {
+	if len(a) == 0 {
+		return errors.New("and: group must not be empty")
+	}
+	for _, e := range a {
+		if e == nil {
+			return errors.New("and: operand must not be nil")
+		}
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a And) render(b *strings.Builder, pos position) {
+	switch len(a) {
+	case 0:
+		return
+	case 1:
+		a[0].render(b, pos)
+		return
+	}
+
+	// GDELT treats whitespace as AND at the top level; nested groups spell
+	// it out and are parenthesised
+	sep := " "
+	if pos != posTop {
+		sep = " AND "
+		b.WriteString("(")
+	}
+	for i, e := range a {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		if pos == posTop {
+			e.render(b, posTop)
+		} else {
+			e.render(b, posOr)
+		}
+	}
+	if pos != posTop {
+		b.WriteString(")")
+	}
+}
+
+// Or matches articles matching any of its operands
+type Or []Expr
+
+func (o Or) Validate() error {
+	if len(o) == 0 {
+		return errors.New("or: group must not be empty")
+	}
+	for _, e := range o {
+		if e == nil {
+			return errors.New("or: operand must not be nil")
+		}
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o Or) render(b *strings.Builder, pos position) {
+	switch len(o) {
+	case 0:
+		return
+	case 1:
+		o[0].render(b, pos)
+		return
+	}
+
+	b.WriteString("(")
+	for i, e := range o {
+		if i > 0 {
+			b.WriteString(" OR ")
+		}
+		e.render(b, posOr)
+	}
+	b.WriteString(")")
+}
+
+// Not excludes articles matching its operand
+type Not struct {
+	Expr Expr
+}
+
+func (n Not) Validate() error {
+	if n.Expr == nil {
+		return errors.New("not: operand must not be nil")
+	}
+	if _, ok := n.Expr.(Not); ok {
+		return errors.New("not: double negation is not supported")
+	}
+	return n.Expr.Validate()
+}
+
+func (n Not) render(b *strings.Builder, _ position) {
+	b.WriteString("-")
+	n.Expr.render(b, posNot)
+}
+
+// Near matches articles where the words appear within Distance words of
+// each other
+type Near struct {
+	Distance int
+	Words    []string
+}
+
+func (n Near) Validate() error {
+	if n.Distance < 1 {
+		return fmt.Errorf("near: distance must be positive, got %d", n.Distance)
+	}
+	if len(n.Words) < 2 {
+		return fmt.Errorf("near: requires at least 2 words, got %d", len(n.Words))
+	}
+	for _, w := range n.Words {
+		if err := Term(w).Validate(); err != nil {
+			return fmt.Errorf("near: %w", err)
+		}
+	}
+	return nil
+}
+
+func (n Near) render(b *strings.Builder, _ position) {
+	fmt.Fprintf(b, "near%d:\"%s\"", n.Distance, strings.Join(n.Words, " "))
+}
+
+// Repeat matches articles mentioning Word at least Count times
+type Repeat struct {
+	Count int
+	Word  string
+}
+
+func (r Repeat) Validate() error {
+	if r.Count < 1 {
+		return fmt.Errorf("repeat: count must be positive, got %d", r.Count)
+	}
+	if err := Term(r.Word).Validate(); err != nil {
+		return fmt.Errorf("repeat: %w", err)
+	}
+	return nil
+}
+
+func (r Repeat) render(b *strings.Builder, _ position) {
+	fmt.Fprintf(b, "repeat%d:\"%s\"", r.Count, r.Word)
+}
+
+// Domain matches articles from a domain or any of its subdomains
+type Domain string
+
+func (d Domain) Validate() error { return validateValue("domain", string(d)) }
+
+func (d Domain) render(b *strings.Builder, _ position) { b.WriteString("domain:" + string(d)) }
+
+// DomainIs matches articles from exactly the given domain
+type DomainIs string
+
+func (d DomainIs) Validate() error { return validateValue("domainis", string(d)) }
+
+func (d DomainIs) render(b *strings.Builder, _ position) { b.WriteString("domainis:" + string(d)) }
+
+// SourceCountry matches articles published in a country (FIPS code)
+type SourceCountry string
+
+func (c SourceCountry) Validate() error { return validateValue("sourcecountry", string(c)) }
+
+func (c SourceCountry) render(b *strings.Builder, _ position) {
+	b.WriteString("sourcecountry:" + string(c))
+}
+
+// SourceLang matches articles published in a language
+type SourceLang string
+
+func (l SourceLang) Validate() error { return validateValue("sourcelang", string(l)) }
+
+func (l SourceLang) render(b *strings.Builder, _ position) { b.WriteString("sourcelang:" + string(l)) }
+
+// Theme matches articles tagged with a GKG theme
+type Theme string
+
+func (t Theme) Validate() error { return validateValue("theme", string(t)) }
+
+func (t Theme) render(b *strings.Builder, _ position) { b.WriteString("theme:" + string(t)) }
+
+// ImageTag matches images tagged by visual analysis
+type ImageTag string
+
+func (t ImageTag) Validate() error { return validateQuoted("imagetag", string(t)) }
+
+func (t ImageTag) render(b *strings.Builder, _ position) {
+	b.WriteString("imagetag:\"" + string(t) + "\"")
+}
+
+// ImageWebTag matches images by tags derived from their web captions
+type ImageWebTag string
+
+func (t ImageWebTag) Validate() error { return validateQuoted("imagewebtag", string(t)) }
+
+func (t ImageWebTag) render(b *strings.Builder, _ position) {
+	b.WriteString("imagewebtag:\"" + string(t) + "\"")
+}
+
+// Op is a comparison operator for tone filters
+type Op string
+
+const (
+	Greater      Op = ">"
+	Less         Op = "<"
+	GreaterEqual Op = ">="
+	LessEqual    Op = "<="
+)
+
+// validate checks the operator is one the API understands
+func (o Op) validate() error {
+	switch o {
+	case Greater, Less, GreaterEqual, LessEqual:
+		return nil
+	}
+	return fmt.Errorf("unsupported comparison operator %q", string(o))
+}
+
+// Tone matches articles whose average tone compares to Value
+type Tone struct {
+	Op    Op
+	Value float64
+}
+
+func (t Tone) Validate() error {
+	if err := t.Op.validate(); err != nil {
+		return fmt.Errorf("tone: %w", err)
+	}
+	return nil
+}
+
+func (t Tone) render(b *strings.Builder, _ position) {
+	b.WriteString("tone" + string(t.Op) + formatNumber(t.Value))
+}
+
+// ToneAbs matches articles whose absolute tone (emotional intensity
+// regardless of polarity) compares to Value
+type ToneAbs struct {
+	Op    Op
+	Value float64
+}
+
+func (t ToneAbs) Validate() error {
+	if err := t.Op.validate(); err != nil {
+		return fmt.Errorf("toneabs: %w", err)
+	}
+	return nil
+}
+
+func (t ToneAbs) render(b *strings.Builder, _ position) {
+	b.WriteString("toneabs" + string(t.Op) + formatNumber(t.Value))
+}
+
+// Raw is a query fragment passed through verbatim, for syntax the tree
+// does not model
+type Raw string
+
+func (r Raw) Validate() error { return nil }
+
+func (r Raw) render(b *strings.Builder, _ position) { b.WriteString(strings.TrimSpace(string(r))) }
+
+// validateValue checks the value of a name:value operator
+func validateValue(name, value string) error {
+	if value == "" {
+		return fmt.Errorf("%s: value must not be empty", name)
+	}
+	if strings.ContainsAny(value, " \t\n\"()") {
+		return fmt.Errorf("%s: invalid value %q", name, value)
+	}
+	return nil
+}
+
+// validateQuoted checks the value of a name:"value" operator
+func validateQuoted(name, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("%s: value must not be empty", name)
+	}
+	if strings.Contains(value, "\"") {
+		return fmt.Errorf("%s: value %q must not contain quotes", name, value)
+	}
+	return nil
+}
+
+// formatNumber renders a tone threshold without trailing zeros
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,172 @@
+package query
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name string
+		expr Expr
+		want string
+	}{
+		{"term", Term("inflation"), `inflation`},
+		{"phrase", Phrase("climate change"), `"climate change"`},
+		{"top level and", And{Phrase("climate change"), Domain("bbc.co.uk")}, `"climate change" domain:bbc.co.uk`},
+		{"or", Or{SourceCountry("US"), SourceCountry("UK")}, `(sourcecountry:US OR sourcecountry:UK)`},
+		{"single element or", Or{Theme("ENV_CLIMATECHANGE")}, `theme:ENV_CLIMATECHANGE`},
+		{
+			"nested boolean",
+			Or{
+				And{Term("A"), Term("B")},
+				And{Term("C"), Not{Term("D")}},
+			},
+			`((A AND B) OR (C AND -D))`,
+		},
+		{"and containing or", And{Term("A"), Or{Term("B"), Term("C")}}, `A (B OR C)`},
+		{"not field", Not{SourceLang("en")}, `-sourcelang:en`},
+		{"not group", Not{Or{Domain("a.com"), Domain("b.com")}}, `-(domain:a.com OR domain:b.com)`},
+		{"near", Near{Distance: 5, Words: []string{"airline", "crisis"}}, `near5:"airline crisis"`},
+		{"repeat", Repeat{Count: 3, Word: "energy"}, `repeat3:"energy"`},
+		{"domainis", DomainIs("nytimes.com"), `domainis:nytimes.com`},
+		{"image tags", And{ImageTag("flood"), ImageWebTag("rescue boat")}, `imagetag:"flood" imagewebtag:"rescue boat"`},
+		{"tone", Tone{Op: Greater, Value: 5}, `tone>5`},
+		{"negative tone", Tone{Op: LessEqual, Value: -2.5}, `tone<=-2.5`},
+		{"toneabs", ToneAbs{Op: GreaterEqual, Value: 10}, `toneabs>=10`},
+		{"raw", Raw(` near5:"a b" `), `near5:"a b"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.expr)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		expr Expr
+	}{
+		{"empty term", Term("")},
+		{"term with space", Term("two words")},
+		{"empty phrase", Phrase(" ")},
+		{"near with one word", Near{Distance: 5, Words: []string{"alone"}}},
+		{"near with zero distance", Near{Distance: 0, Words: []string{"a", "b"}}},
+		{"repeat with phrase", Repeat{Count: 2, Word: "two words"}},
+		{"empty domain", Domain("")},
+		{"bad tone operator", Tone{Op: "=", Value: 1}},
+		{"nil operand", And{Term("a"), nil}},
+		{"nested invalid", Or{Term("a"), And{Term("b"), Term("")}}},
+		{"double negation", Not{Not{Term("a")}}},
+		{"reserved word OR", Or{Term("OR"), Term("AND")}},
+		{"reserved word AND", And{Term("a"), Term("AND")}},
+		{"term with leading dash", Or{Term("-x"), Term("y")}},
+		{"term with parenthesis", Term("a)")},
+		{"empty and", And{}},
+		{"empty or", Or{}},
+		{"nested empty group", And{Term("a"), Or{}, Term("b")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Render(tt.expr); err == nil {
+				t.Errorf("Render() expected error")
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Expr
+	}{
+		{``, And{}},
+		{`inflation`, Term("inflation")},
+		{`"climate change" domain:bbc.co.uk`, And{Phrase("climate change"), Domain("bbc.co.uk")}},
+		{`(sourcecountry:US OR sourcecountry:UK)`, Or{SourceCountry("US"), SourceCountry("UK")}},
+		{`a OR b c`, Or{Term("a"), And{Term("b"), Term("c")}}},
+		{`((A AND B) OR (C AND -D))`, Or{And{Term("A"), Term("B")}, And{Term("C"), Not{Expr: Term("D")}}}},
+		{`-sourcelang:en -"breaking news"`, And{Not{Expr: SourceLang("en")}, Not{Expr: Phrase("breaking news")}}},
+		{`near5:"airline crisis" repeat3:"energy"`, And{Near{Distance: 5, Words: []string{"airline", "crisis"}}, Repeat{Count: 3, Word: "energy"}}},
+		{`tone<=-2.5 toneabs>10`, And{Tone{Op: LessEqual, Value: -2.5}, ToneAbs{Op: Greater, Value: 10}}},
+		{`domainis:nytimes.com theme:ENV_CLIMATECHANGE`, And{DomainIs("nytimes.com"), Theme("ENV_CLIMATECHANGE")}},
+		{`imagetag:"flood" imagewebtag:"rescue boat"`, And{ImageTag("flood"), ImageWebTag("rescue boat")}},
+		{`imageocrmeta:"protest" covid-19`, And{Raw(`imageocrmeta:"protest"`), Term("covid-19")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	inputs := []string{
+		`"climate change" (domain:bbc.co.uk OR domain:nytimes.com) sourcelang:en`,
+		`((A AND B) OR (C AND -D))`,
+		`-(domain:a.com OR domain:b.com) tone>5`,
+		`A (B OR C) near10:"airline climate change"`,
+	}
+
+	for _, input := range inputs {
+		expr, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", input, err)
+		}
+		if got := MustRender(expr); got != input {
+			t.Errorf("Render(Parse(%q)) = %q", input, got)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int
+	}{
+		{`"climate change`, 0},
+		{`economy (domain:a.com OR domain:b.com`, 8},
+		{`economy domain:a.com)`, 20},
+		{`(a OR )`, 6},
+		{`OR economy`, 0},
+		{`economy AND`, 11},
+		{`economy - crisis`, 8},
+		{`near5:"alone"`, 0},
+		{`near:"a b"`, 0},
+		{`economy tone>high`, 13},
+		{`economy domain:`, 15},
+		{`repeat2:"two words"`, 0},
+		{`abc"def"`, 3},
+		{`sourcecountry:US ()`, 18},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Parse() error = %v, want *SyntaxError", err)
+			}
+			if syntaxErr.Offset != tt.offset {
+				t.Errorf("Parse() error offset = %d, want %d (%v)", syntaxErr.Offset, tt.offset, err)
+			}
+		})
+	}
+}
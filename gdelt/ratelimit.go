@@ -0,0 +1,129 @@
+package gdelt
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultRequestInterval is GDELT's guidance for sustained use of the DOC
+// API: no more than one request every five seconds
+const DefaultRequestInterval = 5 * time.Second
+
+// RateLimiter is a token bucket shared by every request a client makes.
+// Callers that exceed the rate queue in Wait instead of tripping 429s.
+// A single RateLimiter may be shared by several clients.
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    int
+	tokens   float64
+	last     time.Time
+	stats    RateLimiterStats
+}
+
+// RateLimiterStats reports how much the limiter has delayed requests
+type RateLimiterStats struct {
+	Requests  int64         // total calls to Wait that were admitted
+	Delayed   int64         // calls that had to wait for a token
+	TotalWait time.Duration // cumulative time spent waiting
+	MaxWait   time.Duration // longest single wait
+}
+
+// NewRateLimiter creates a limiter that admits one request every interval,
+// allowing bursts of up to burst requests
+func NewRateLimiter(interval time.Duration, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		interval: interval,
+		burst:    burst,
+		tokens:   float64(burst),
+	}
+}
+
+// DefaultRateLimiter returns a limiter following GDELT's guidance of one
+// request every five seconds, without bursts
+func DefaultRateLimiter() *RateLimiter {
+	return NewRateLimiter(DefaultRequestInterval, 1)
+}
+
+// Wait blocks until a request may be made or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	delay := l.reserve(time.Now())
+	if delay <= 0 {
+		l.record(0)
+		return nil
+	}
+
+	if err := sleepContext(ctx, delay); err != nil {
+		l.cancel()
+		return err
+	}
+	l.record(delay)
+	return nil
+}
+
+// Stats returns a snapshot of the limiter statistics
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
+
+// reserve takes a token, returning how long the caller must wait for it
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.interval <= 0 {
+		return 0
+	}
+
+	if !l.last.IsZero() && now.After(l.last) {
+		l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+		if l.tokens > float64(l.burst) {
+			l.tokens = float64(l.burst)
+		}
+	}
+	if now.After(l.last) {
+		l.last = now
+	}
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens * float64(l.interval))
+}
+
+// cancel returns a token reserved by a caller that gave up waiting
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens++
+	if l.tokens > float64(l.burst) {
+		l.tokens = float64(l.burst)
+	}
+}
+
+// record updates the statistics for an admitted request
+func (l *RateLimiter) record(wait time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stats.Requests++
+	if wait > 0 {
+		l.stats.Delayed++
+		l.stats.TotalWait += wait
+		if wait > l.stats.MaxWait {
+			l.stats.MaxWait = wait
+		}
+	}
+}
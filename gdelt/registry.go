@@ -0,0 +1,233 @@
+package gdelt
+
+//go:generate go run ./internal/cmd/genregistry -countries data/countries.tsv -languages data/languages.tsv -themes data/themes.tsv -o registry_gen.go
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Country is a country GDELT can filter sources by
+type Country struct {
+	FIPS    string   // FIPS 10-4 code, as used by sourcecountry
+	ISO     string   // ISO 3166-1 alpha-2 code, empty if there is none
+	Name    string   // Name GDELT reports on articles
+	Aliases []string // Other names the country goes by
+}
+
+// Language is a language GDELT monitors
+type Language struct {
+	Code    string   // ISO 639-1 code
+	ISO3    string   // ISO 639-3 code
+	Name    string   // Name GDELT reports on articles
+	Aliases []string // Other names the language goes by
+}
+
+// Registry indexes, built from the generated tables
+var (
+	countryByFIPS  = make(map[string]*Country)
+	countryByISO   = make(map[string]*Country)
+	countryByName  = make(map[string]*Country)
+	languageByCode = make(map[string]*Language)
+	languageByName = make(map[string]*Language)
+)
+
+func init() {
+	for i := range countries {
+		c := &countries[i]
+		countryByFIPS[c.FIPS] = c
+		if c.ISO != "" {
+			countryByISO[c.ISO] = c
+		}
+		for _, name := range append([]string{c.Name}, c.Aliases...) {
+			countryByName[nameKey(name)] = c
+		}
+	}
+	for i := range languages {
+		l := &languages[i]
+		languageByCode[l.Code] = l
+		languageByCode[l.ISO3] = l
+		for _, name := range append([]string{l.Name}, l.Aliases...) {
+			languageByName[nameKey(name)] = l
+		}
+	}
+}
+
+// nameKey folds a name for lookups, so "United States", "united-states" and
+// GDELT's "unitedstates" all match
+func nameKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Countries returns every country GDELT accepts, ordered by name
+func Countries() []Country {
+	return append([]Country(nil), countries...)
+}
+
+// Languages returns every language GDELT monitors, ordered by name
+func Languages() []Language {
+	return append([]Language(nil), languages...)
+}
+
+// LookupCountry finds a country by FIPS code or by name, ignoring case,
+// spaces and punctuation
+func LookupCountry(s string) (Country, bool) {
+	if c, ok := countryByFIPS[strings.ToUpper(strings.TrimSpace(s))]; ok {
+		return *c, true
+	}
+	if c, ok := countryByName[nameKey(s)]; ok {
+		return *c, true
+	}
+	return Country{}, false
+}
+
+// LookupLanguage finds a language by ISO 639-1 or 639-3 code or by name,
+// ignoring case, spaces and punctuation
+func LookupLanguage(s string) (Language, bool) {
+	if l, ok := languageByCode[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return *l, true
+	}
+	if l, ok := languageByName[nameKey(s)]; ok {
+		return *l, true
+	}
+	return Language{}, false
+}
+
+// FIPSToISO converts a FIPS 10-4 country code to ISO 3166-1 alpha-2. It
+// reports false for unknown codes and for places without an ISO code.
+func FIPSToISO(fips string) (string, bool) {
+	c, ok := countryByFIPS[strings.ToUpper(fips)]
+	if !ok || c.ISO == "" {
+		return "", false
+	}
+	return c.ISO, true
+}
+
+// ISOToFIPS converts an ISO 3166-1 alpha-2 country code to FIPS 10-4
+func ISOToFIPS(iso string) (string, bool) {
+	c, ok := countryByISO[strings.ToUpper(iso)]
+	if !ok {
+		return "", false
+	}
+	return c.FIPS, true
+}
+
+// UnknownCodeError reports a country, language or theme missing from the
+// registries, with the closest known code
+type UnknownCodeError struct {
+	Kind       string // "country", "language" or "theme"
+	Code       string
+	Suggestion string // Closest known code, if any
+	Name       string // Name of the suggested code
+}
+
+func (e *UnknownCodeError) Error() string {
+	if e.Suggestion == "" {
+		return fmt.Sprintf("unknown %s code %q", e.Kind, e.Code)
+	}
+	return fmt.Sprintf("unknown %s code %q; did you mean %q (%s)?", e.Kind, e.Code, e.Suggestion, e.Name)
+}
+
+// ValidateCountry checks that s is a FIPS code or country name GDELT
+// accepts, returning an *UnknownCodeError otherwise
+func ValidateCountry(s string) error {
+	if _, ok := LookupCountry(s); ok {
+		return nil
+	}
+
+	err := &UnknownCodeError{Kind: "country", Code: s}
+	var match *Country
+	if c, ok := countryByISO[strings.ToUpper(s)]; ok {
+		// The most common mistake: an ISO code where FIPS is expected
+		match = c
+	} else if len(s) <= 2 {
+		match = closest(countryByFIPS, strings.ToUpper(s))
+	} else {
+		match = closest(countryByName, nameKey(s))
+	}
+	if match != nil {
+		err.Suggestion, err.Name = match.FIPS, match.Name
+	}
+	return err
+}
+
+// ValidateLanguage checks that s is a language code or name GDELT accepts,
+// returning an *UnknownCodeError otherwise
+func ValidateLanguage(s string) error {
+	if _, ok := LookupLanguage(s); ok {
+		return nil
+	}
+
+	err := &UnknownCodeError{Kind: "language", Code: s}
+	var match *Language
+	if len(s) <= 3 {
+		match = closest(languageByCode, strings.ToLower(s))
+	} else {
+		match = closest(languageByName, nameKey(s))
+	}
+	if match != nil {
+		err.Suggestion, err.Name = match.Code, match.Name
+	}
+	return err
+}
+
+// closest returns the entry whose key is the fewest edits away from s,
+// breaking ties by the smaller key
+func closest[T any](index map[string]*T, s string) *T {
+	var best *T
+	bestKey, bestDist := "", -1
+	for key, v := range index {
+		d := editDistance(s, key)
+		if bestDist < 0 || d < bestDist || d == bestDist && key < bestKey {
+			best, bestKey, bestDist = v, key, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// normalizeLanguage returns the ISO 639-1 code for a GDELT language name,
+// or "" if it is not recognised
+func normalizeLanguage(name string) string {
+	if l, ok := languageByName[nameKey(name)]; ok {
+		return l.Code
+	}
+	return ""
+}
+
+// normalizeCountry returns the FIPS code for a GDELT country name, or "" if
+// it is not recognised
+func normalizeCountry(name string) string {
+	if c, ok := countryByName[nameKey(name)]; ok {
+		return c.FIPS
+	}
+	return ""
+}
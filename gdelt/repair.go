@@ -0,0 +1,337 @@
+package gdelt
+
+import (
+	"errors"
+	"fmt"
+)
+
+// RepairWarning describes one change made to a malformed response so it
+// could be decoded
+type RepairWarning struct {
+	Offset  int // Byte offset in the original response
+	Message string
+}
+
+func (w RepairWarning) String() string {
+	return fmt.Sprintf("offset %d: %s", w.Offset, w.Message)
+}
+
+// repairer rewrites a malformed JSON document into a valid one. It tracks
+// just enough structure (open containers, whether a key is expected) to
+// tell a closing quote from a stray one and to close a truncated body.
+type repairer struct {
+	in       []byte
+	out      []byte
+	stack    []byte // open containers, '{' or '['
+	keyNext  bool   // the next string in the innermost object is a key
+	maxDepth int
+
+	// The last point at which out held complete values only, used to drop
+	// a partial value from a truncated body
+	safeLen   int
+	safeStack []byte
+
+	warnings []RepairWarning
+}
+
+// repairJSON fixes the ways GDELT is known to break JSON: raw control
+// characters, invalid escapes such as \x27 or \', unescaped quotes inside
+// strings, trailing commas and bodies cut off mid-value. It returns the
+// repaired document and what was changed; no warnings means data was left
+// as is. Nesting deeper than maxDepth is refused.
+func repairJSON(data []byte, maxDepth int) ([]byte, []RepairWarning, error) {
+	r := &repairer{
+		in:       data,
+		out:      make([]byte, 0, len(data)+16),
+		maxDepth: maxDepth,
+	}
+	if err := r.run(); err != nil {
+		return nil, nil, err
+	}
+	return r.out, r.warnings, nil
+}
+
+func (r *repairer) warn(offset int, format string, args ...interface{}) {
+	r.warnings = append(r.warnings, RepairWarning{Offset: offset, Message: fmt.Sprintf(format, args...)})
+}
+
+// markSafe records that out currently ends after a complete value
+func (r *repairer) markSafe() {
+	r.safeLen = len(r.out)
+	r.safeStack = append(r.safeStack[:0], r.stack...)
+}
+
+func (r *repairer) top() byte {
+	if len(r.stack) == 0 {
+		return 0
+	}
+	return r.stack[len(r.stack)-1]
+}
+
+func (r *repairer) run() error {
+	complete := true
+	for i := 0; i < len(r.in); {
+		c := r.in[i]
+		switch {
+		case c == '"':
+			var ok bool
+			if i, ok = r.string(i); !ok {
+				complete = false
+			}
+		case c == '{' || c == '[':
+			if len(r.stack) >= r.maxDepth {
+				return fmt.Errorf("nesting deeper than %d at offset %d", r.maxDepth, i)
+			}
+			r.stack = append(r.stack, c)
+			r.out = append(r.out, c)
+			r.keyNext = c == '{'
+			r.markSafe()
+			i++
+		case c == '}' || c == ']':
+			r.close(i, c)
+			i++
+		case c == ':':
+			r.out = append(r.out, c)
+			r.keyNext = false
+			i++
+		case c == ',':
+			r.out = append(r.out, c)
+			r.keyNext = r.top() == '{'
+			i++
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			r.out = append(r.out, c)
+			i++
+		case c < 0x20:
+			r.warn(i, "removed control character %#02x", c)
+			i++
+		default:
+			// Numbers, true, false, null
+			j := i
+			for j < len(r.in) && !isLiteralEnd(r.in[j]) {
+				j++
+			}
+			r.out = append(r.out, r.in[i:j]...)
+			if j == len(r.in) {
+				complete = false
+			} else {
+				r.markSafe()
+			}
+			i = j
+		}
+	}
+
+	if !complete || len(r.stack) > 0 {
+		r.out = r.out[:r.safeLen]
+		r.stack = r.safeStack
+		r.warn(len(r.in), "body is truncated; dropped the incomplete value and closed %d open containers", len(r.stack))
+		for len(r.stack) > 0 {
+			r.close(len(r.in), closerOf(r.top()))
+		}
+	}
+	if len(r.out) == 0 {
+		return errors.New("no JSON value to repair")
+	}
+	return nil
+}
+
+// close ends the innermost container at offset, dropping a trailing comma
+// before it and correcting a mismatched or unmatched closer
+func (r *repairer) close(offset int, c byte) {
+	if len(r.stack) == 0 {
+		r.warn(offset, "removed unmatched %q", c)
+		return
+	}
+	if want := closerOf(r.top()); c != want {
+		r.warn(offset, "replaced mismatched %q with %q", c, want)
+		c = want
+	}
+
+	end := len(r.out)
+	for end > 0 && isSpace(r.out[end-1]) {
+		end--
+	}
+	if end > 0 && r.out[end-1] == ',' {
+		r.warn(offset, "removed trailing comma")
+		r.out = append(r.out[:end-1], r.out[end:]...)
+	}
+
+	r.stack = r.stack[:len(r.stack)-1]
+	r.out = append(r.out, c)
+	r.keyNext = false
+	r.markSafe()
+}
+
+// string copies the string starting at the quote at offset i, fixing its
+// contents. It returns the offset after the string and false if the input
+// ended first.
+func (r *repairer) string(i int) (int, bool) {
+	isKey := r.top() == '{' && r.keyNext
+	r.out = append(r.out, '"')
+
+	for j := i + 1; j < len(r.in); {
+		c := r.in[j]
+		switch {
+		case c == '\\':
+			if j+1 >= len(r.in) {
+				return len(r.in), false
+			}
+			switch n := r.in[j+1]; n {
+			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+				r.out = append(r.out, c, n)
+				j += 2
+			case 'u':
+				if j+6 > len(r.in) {
+					return len(r.in), false
+				}
+				if isHex(r.in[j+2 : j+6]) {
+					r.out = append(r.out, r.in[j:j+6]...)
+					j += 6
+				} else {
+					r.warn(j, "escaped backslash before invalid \\u escape")
+					r.out = append(r.out, '\\', '\\')
+					j++
+				}
+			case 'x':
+				if j+4 > len(r.in) {
+					return len(r.in), false
+				}
+				if isHex(r.in[j+2 : j+4]) {
+					r.warn(j, "rewrote \\x%s escape as \\u00%s", r.in[j+2:j+4], r.in[j+2:j+4])
+					r.out = append(r.out, `\u00`...)
+					r.out = append(r.out, r.in[j+2:j+4]...)
+					j += 4
+				} else {
+					r.warn(j, "escaped backslash before invalid \\x escape")
+					r.out = append(r.out, '\\', '\\')
+					j++
+				}
+			case '\'':
+				r.warn(j, "removed backslash before '")
+				r.out = append(r.out, '\'')
+				j += 2
+			default:
+				r.warn(j, "escaped stray backslash before %q", n)
+				r.out = append(r.out, '\\', '\\')
+				j++
+			}
+		case c == '"':
+			if r.closesString(j+1, isKey) {
+				r.out = append(r.out, '"')
+				if !isKey {
+					r.markSafe()
+				}
+				return j + 1, true
+			}
+			r.warn(j, "escaped unescaped quote inside string")
+			r.out = append(r.out, '\\', '"')
+			j++
+		case c == '\n':
+			r.warn(j, "escaped raw newline inside string")
+			r.out = append(r.out, '\\', 'n')
+			j++
+		case c == '\r':
+			r.warn(j, "escaped raw carriage return inside string")
+			r.out = append(r.out, '\\', 'r')
+			j++
+		case c == '\t':
+			r.warn(j, "escaped raw tab inside string")
+			r.out = append(r.out, '\\', 't')
+			j++
+		case c < 0x20:
+			r.warn(j, "removed control character %#02x", c)
+			j++
+		default:
+			r.out = append(r.out, c)
+			j++
+		}
+	}
+	return len(r.in), false
+}
+
+// closesString reports whether a quote followed by the input at offset k
+// ends the current string, judged by what may legally come next
+func (r *repairer) closesString(k int, isKey bool) bool {
+	k = r.skipSpace(k)
+	if k >= len(r.in) {
+		return true
+	}
+	if isKey {
+		return r.in[k] == ':'
+	}
+
+	switch r.in[k] {
+	case '}', ']', ':':
+		return true
+	case ',':
+		k = r.skipSpace(k + 1)
+		if k >= len(r.in) || r.in[k] == '}' || r.in[k] == ']' {
+			return true // truncated, or a trailing comma
+		}
+		switch r.top() {
+		case '{':
+			return r.in[k] == '"' && r.isKeyAt(k)
+		case '[':
+			return isValueStart(r.in[k])
+		}
+		return true
+	}
+	return false
+}
+
+// isKeyAt reports whether the string starting at the quote at offset k is
+// followed by a colon, i.e. is an object key
+func (r *repairer) isKeyAt(k int) bool {
+	for j := k + 1; j < len(r.in); j++ {
+		switch r.in[j] {
+		case '\\':
+			j++
+		case '"':
+			j = r.skipSpace(j + 1)
+			return j >= len(r.in) || r.in[j] == ':'
+		}
+	}
+	return true // truncated key
+}
+
+func (r *repairer) skipSpace(k int) int {
+	for k < len(r.in) && isSpace(r.in[k]) {
+		k++
+	}
+	return k
+}
+
+func closerOf(open byte) byte {
+	if open == '{' {
+		return '}'
+	}
+	return ']'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isLiteralEnd(c byte) bool {
+	switch c {
+	case ',', '}', ']', ':', '"', '{', '[':
+		return true
+	}
+	return c <= ' '
+}
+
+func isValueStart(c byte) bool {
+	switch c {
+	case '"', '{', '[', '-', 't', 'f', 'n':
+		return true
+	}
+	return c >= '0' && c <= '9'
+}
+
+func isHex(b []byte) bool {
+	for _, c := range b {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,175 @@
+package gdelt
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures automatic retries of transient API errors.
+// Only RateLimitError and retryable ServerError responses are retried; every
+// API call is a GET, so repeating it is always safe.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values of 1 or less disable retries.
+	MaxAttempts int
+	// BaseDelay is the backoff before the second attempt; it doubles on
+	// every subsequent attempt
+	BaseDelay time.Duration
+	// MaxDelay caps the exponential backoff (zero means no cap). A
+	// Retry-After longer than MaxDelay isn't waited for: the error is
+	// returned instead.
+	MaxDelay time.Duration
+	// Jitter is the fraction (0-1) of each backoff that is randomised
+	Jitter float64
+	// OnAttempt, if set, is called after every attempt
+	OnAttempt func(RetryAttempt)
+}
+
+// RetryAttempt describes the outcome of a single attempt
+type RetryAttempt struct {
+	Attempt   int           // 1-based attempt number
+	Err       error         // error returned by the attempt, nil on success
+	Delay     time.Duration // wait before the next attempt
+	WillRetry bool          // whether another attempt will be made
+}
+
+// DefaultRetryPolicy returns the policy used by new clients: three attempts
+// with exponential backoff starting at one second
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Second,
+		MaxDelay:    30 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// NoRetry returns a policy that never retries
+func NoRetry() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// next reports whether the attempt that returned err should be retried and
+// how long to wait first
+func (p RetryPolicy) next(attempt int, err error) (time.Duration, bool) {
+	if err == nil || attempt >= p.MaxAttempts || !IsRetryable(err) {
+		return 0, false
+	}
+
+	delay := p.backoff(attempt)
+	if retryAfter := retryAfterOf(err); retryAfter > delay {
+		// Retrying sooner than asked would only be refused again
+		if p.MaxDelay > 0 && retryAfter > p.MaxDelay {
+			return 0, false
+		}
+		delay = retryAfter
+	}
+	return delay, true
+}
+
+// backoff computes the jittered exponential delay after the given attempt
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 && delay > 0 {
+		jitter := min(p.Jitter, 1)
+		delay -= time.Duration(rand.Float64() * jitter * float64(delay))
+	}
+	return delay
+}
+
+// IsRetryable reports whether err is a transient API error worth retrying
+func IsRetryable(err error) bool {
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return true
+	}
+
+	var serverErr *ServerError
+	if errors.As(err, &serverErr) {
+		switch serverErr.StatusCode {
+		case http.StatusNotImplemented, http.StatusHTTPVersionNotSupported:
+			return false
+		}
+		return true
+	}
+
+	return false
+}
+
+// retryAfterOf extracts the server-requested delay from err, if any
+func retryAfterOf(err error) time.Duration {
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return rateLimitErr.RetryAfter
+	}
+
+	var serverErr *ServerError
+	if errors.As(err, &serverErr) {
+		return serverErr.RetryAfter
+	}
+
+	return 0
+}
+
+// withRetryAfter records the Retry-After header on retryable errors
+func withRetryAfter(err error, header string) error {
+	retryAfter := parseRetryAfter(header, time.Now())
+	switch e := err.(type) {
+	case *RateLimitError:
+		e.RetryAfter = retryAfter
+	case *ServerError:
+		e.RetryAfter = retryAfter
+	}
+	return err
+}
+
+// parseRetryAfter parses a Retry-After header given either as delta seconds
+// or as an HTTP date
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
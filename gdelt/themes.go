@@ -0,0 +1,162 @@
+package gdelt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Theme is a GKG theme GDELT can filter on. Names ending in "_*" are
+// families covering every theme that starts with the prefix before the *.
+type Theme struct {
+	Name        string
+	Description string
+}
+
+// IsFamily reports whether t stands for a family of themes
+func (t Theme) IsFamily() bool {
+	return strings.HasSuffix(t.Name, "*")
+}
+
+// Theme indexes, built from the generated table
+var (
+	themeByName   = make(map[string]*Theme) // exact themes only
+	themeFamilies []*Theme
+)
+
+func init() {
+	for i := range themes {
+		t := &themes[i]
+		if t.IsFamily() {
+			themeFamilies = append(themeFamilies, t)
+		} else {
+			themeByName[t.Name] = t
+		}
+	}
+}
+
+// Themes returns the theme catalog, ordered by name
+func Themes() []Theme {
+	return append([]Theme(nil), themes...)
+}
+
+// LookupTheme returns the catalog entry for a theme, ignoring case. A
+// theme that belongs to a family returns the family's entry.
+func LookupTheme(name string) (Theme, bool) {
+	upper := strings.ToUpper(strings.TrimSpace(name))
+	if t, ok := themeByName[upper]; ok {
+		return *t, true
+	}
+	for _, f := range themeFamilies {
+		prefix := strings.TrimSuffix(f.Name, "*")
+		if len(upper) > len(prefix) && strings.HasPrefix(upper, prefix) && isThemeName(upper) {
+			return *f, true
+		}
+	}
+	return Theme{}, false
+}
+
+// ValidateTheme checks that name is in the bundled theme catalog, returning
+// an *UnknownCodeError naming the closest theme otherwise
+func ValidateTheme(name string) error {
+	if _, ok := LookupTheme(name); ok {
+		return nil
+	}
+
+	err := &UnknownCodeError{Kind: "theme", Code: name}
+	if t := closest(themeByName, strings.ToUpper(name)); t != nil {
+		err.Suggestion, err.Name = t.Name, t.Description
+	}
+	return err
+}
+
+// checkThemeName checks that name is spelled like a GKG theme, without
+// requiring it to be in the catalog
+func checkThemeName(name string) error {
+	if !isThemeName(strings.ToUpper(name)) {
+		return fmt.Errorf("theme %q must only contain letters, digits and underscores", name)
+	}
+	return nil
+}
+
+// SearchThemes finds themes for autocomplete. Matches are ranked: the exact
+// theme, then themes starting with q, then themes containing q in their
+// name or description, then themes within a few typos of q. At most limit
+// themes are returned; limit <= 0 returns every match.
+func SearchThemes(q string, limit int) []Theme {
+	q = strings.ToUpper(strings.TrimSpace(q))
+	if q == "" {
+		return nil
+	}
+	squashed := squashTheme(q)
+	maxTypos := max(1, len(q)/3)
+
+	type match struct {
+		theme *Theme
+		rank  int
+	}
+	var matches []match
+	for i := range themes {
+		t := &themes[i]
+		name := strings.TrimSuffix(t.Name, "*")
+		rank := -1
+		switch {
+		case name == q:
+			rank = 0
+		case strings.HasPrefix(name, q):
+			rank = 1
+		case strings.Contains(squashTheme(name), squashed):
+			rank = 2
+		case strings.Contains(strings.ToUpper(t.Description), q):
+			rank = 3
+		case withinTypos(name, q, maxTypos):
+			rank = 4
+		}
+		if rank >= 0 {
+			matches = append(matches, match{t, rank})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].rank < matches[j].rank
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	result := make([]Theme, len(matches))
+	for i, m := range matches {
+		result[i] = *m.theme
+	}
+	return result
+}
+
+// withinTypos reports whether q is at most maxTypos edits away from the
+// start of name or of one of its underscore-separated parts
+func withinTypos(name, q string, maxTypos int) bool {
+	for _, part := range append([]string{name}, strings.Split(name, "_")...) {
+		if len(part) > len(q) {
+			part = part[:len(q)]
+		}
+		if editDistance(part, q) <= maxTypos {
+			return true
+		}
+	}
+	return false
+}
+
+// squashTheme drops underscores and spaces, so "climate change" matches
+// ENV_CLIMATECHANGE
+func squashTheme(s string) string {
+	return strings.NewReplacer("_", "", " ", "").Replace(s)
+}
+
+// isThemeName reports whether s only uses the characters of theme names
+func isThemeName(s string) bool {
+	for _, r := range s {
+		if !(r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,168 @@
+package gdelt
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TimespanUnit is the unit of a Timespan
+type TimespanUnit string
+
+const (
+	TimespanMinutes TimespanUnit = "min"
+	TimespanHours   TimespanUnit = "h"
+	TimespanDays    TimespanUnit = "d"
+	TimespanWeeks   TimespanUnit = "w"
+	TimespanMonths  TimespanUnit = "months"
+)
+
+// timespanUnits maps every spelling the API accepts to its unit. GDELT
+// reads a bare "m" as months, not minutes.
+var timespanUnits = map[string]TimespanUnit{
+	"min": TimespanMinutes,
+	"h":   TimespanHours, "hours": TimespanHours,
+	"d": TimespanDays, "days": TimespanDays,
+	"w": TimespanWeeks, "weeks": TimespanWeeks,
+	"m": TimespanMonths, "months": TimespanMonths,
+}
+
+// nominal is the length of one unit used to compare timespans; a month
+// counts as 30 days
+var nominal = map[TimespanUnit]time.Duration{
+	TimespanMinutes: time.Minute,
+	TimespanHours:   time.Hour,
+	TimespanDays:    24 * time.Hour,
+	TimespanWeeks:   7 * 24 * time.Hour,
+	TimespanMonths:  30 * 24 * time.Hour,
+}
+
+// MinTimespan is the shortest timespan the API accepts
+var MinTimespan = Timespan{Value: 60, Unit: TimespanMinutes}
+
+// MaxLookback is how far back the DOC API searches: a rolling window of
+// the last three months. Longer timespans and dates before it are rejected.
+var MaxLookback = Timespan{Value: 3, Unit: TimespanMonths}
+
+// Timespan is a window relative to the time of the query, such as the last
+// 24 hours. The zero value means no timespan.
+type Timespan struct {
+	Value int
+	Unit  TimespanUnit
+}
+
+// ParseTimespan parses a timespan such as "90min", "24h", "7days" or
+// "3months" and validates it. A bare "m" means months, as it does to the
+// API.
+func ParseTimespan(s string) (Timespan, error) {
+	i := 0
+	for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+		i++
+	}
+	if i == 0 || i == len(s) {
+		return Timespan{}, fmt.Errorf("timespan %q must be a number followed by a unit", s)
+	}
+
+	unit, ok := timespanUnits[s[i:]]
+	if !ok {
+		return Timespan{}, fmt.Errorf("timespan unit %s is not supported (must be one of: min, h, hours, d, days, w, weeks, m, months)", s[i:])
+	}
+	value, err := strconv.Atoi(s[:i])
+	if err != nil {
+		return Timespan{}, fmt.Errorf("timespan value %s is not a valid integer", s[:i])
+	}
+
+	t := Timespan{Value: value, Unit: unit}
+	if err := t.Validate(); err != nil {
+		if s[i:] == "m" {
+			return Timespan{}, fmt.Errorf("%w (m means months; use min for minutes)", err)
+		}
+		return Timespan{}, err
+	}
+	return t, nil
+}
+
+// MustParseTimespan parses a timespan and panics on error
+func MustParseTimespan(s string) Timespan {
+	t, err := ParseTimespan(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// IsZero reports whether t is unset
+func (t Timespan) IsZero() bool {
+	return t == Timespan{}
+}
+
+// String formats t as the API expects it, e.g. "24h" or "3months"
+func (t Timespan) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return strconv.Itoa(t.Value) + string(t.Unit)
+}
+
+// Validate checks that t has a known unit and lies between MinTimespan and
+// MaxLookback
+func (t Timespan) Validate() error {
+	if _, ok := nominal[t.Unit]; !ok {
+		return fmt.Errorf("timespan unit %q is not supported", string(t.Unit))
+	}
+	if t.Value <= 0 {
+		return fmt.Errorf("timespan value must be positive, got %d", t.Value)
+	}
+	if t.Compare(MinTimespan) < 0 {
+		return fmt.Errorf("timespan %s is shorter than the minimum of %s", t, MinTimespan)
+	}
+	if t.Compare(MaxLookback) > 0 {
+		return fmt.Errorf("timespan %s is longer than the maximum lookback of %s", t, MaxLookback)
+	}
+	return nil
+}
+
+// Compare returns -1, 0 or +1 as t is shorter than, as long as or longer
+// than u. Months count as 30 days; use Window for exact calendar bounds.
+func (t Timespan) Compare(u Timespan) int {
+	a, b := t.nominal(), u.nominal()
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func (t Timespan) nominal() time.Duration {
+	return time.Duration(t.Value) * nominal[t.Unit]
+}
+
+// Window returns the absolute window [start, end) that t covers when the
+// query is made at now. Months are calendar months.
+func (t Timespan) Window(now time.Time) (start, end time.Time) {
+	if t.Unit == TimespanMonths {
+		return now.AddDate(0, -t.Value, 0), now
+	}
+	return now.Add(-t.nominal()), now
+}
+
+// validateWindow checks a StartDate/EndDate window against the range the
+// API can search at now: it must be non-empty, start no earlier than
+// MaxLookback before now and not start in the future
+func validateWindow(start, end, now time.Time) error {
+	if !start.Before(end) {
+		return &ValidationError{Field: "EndDate", Reason: "must be after StartDate"}
+	}
+	if earliest, _ := MaxLookback.Window(now); start.Before(earliest) {
+		return &ValidationError{
+			Field:  "StartDate",
+			Reason: fmt.Sprintf("%s is before %s, the start of the API's %s lookback", start.UTC().Format(time.DateTime), earliest.UTC().Format(time.DateTime), MaxLookback),
+		}
+	}
+	if start.After(now) {
+		return &ValidationError{Field: "StartDate", Reason: fmt.Sprintf("%s is in the future", start.UTC().Format(time.DateTime))}
+	}
+	return nil
+}
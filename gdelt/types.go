@@ -17,13 +17,67 @@ type Article struct {
 	Domain        string `json:"domain"`
 	Language      string `json:"language"`
 	SourceCountry string `json:"sourcecountry"`
+
+	// Normalized from the raw fields above when the article is decoded
+	Seen         time.Time `json:"-"`
+	LanguageCode string    `json:"-"` // ISO 639-1, empty if unrecognised
+	CountryCode  string    `json:"-"` // FIPS 10-4, empty if unrecognised
 }
 
-// GetSeenTime parses the seendate field into time.Time
+// GetSeenTime returns the time the article was seen, parsing the seendate
+// field if the article was not normalized on decoding
 func (a *Article) GetSeenTime() (time.Time, error) {
+	if !a.Seen.IsZero() {
+		return a.Seen, nil
+	}
 	return time.Parse("20060102T150405Z", a.SeenDate)
 }
 
+// normalize fills the parsed and normalized fields from the raw ones
+func (a *Article) normalize() {
+	a.Seen, _ = time.Parse("20060102T150405Z", a.SeenDate)
+	a.LanguageCode = normalizeLanguage(a.Language)
+	a.CountryCode = normalizeCountry(a.SourceCountry)
+}
+
+// ArticleList is an article list search result
+type ArticleList struct {
+	Articles []Article
+	Warnings []RepairWarning // Repairs made to a malformed response
+}
+
+// Image represents a single image from the image modes
+type Image struct {
+	URL        string `json:"url"`           // URL of the image itself
+	ArticleURL string `json:"articleurl"`    // Article the image appeared in
+	WebCount   int    `json:"imagewebcount"` // Times the image has been seen on the web
+	Date       string `json:"date"`
+}
+
+// ImageList is the result of the image modes
+type ImageList struct {
+	Images   []Image
+	Warnings []RepairWarning // Repairs made to a malformed response
+}
+
+// GetDate parses the date field into time.Time
+func (i *Image) GetDate() (time.Time, error) {
+	return time.Parse("20060102T150405Z", i.Date)
+}
+
+// TermWeight is a single term of a word cloud with its frequency
+type TermWeight struct {
+	Term   string  `json:"label"`
+	Weight float64 `json:"count"`
+}
+
+// WordCloud is the result of the word cloud modes, ordered from most to
+// least frequent term
+type WordCloud struct {
+	Terms    []TermWeight
+	Warnings []RepairWarning // Repairs made to a malformed response
+}
+
 // TimelineResponse is the response from timeline modes
 type TimelineResponse struct {
 	QueryDetails QueryDetails `json:"query_details"`
@@ -44,16 +98,18 @@ type TimelineSeries struct {
 
 // TimelineData is a single data point in a timeline
 type TimelineData struct {
-	Date  string  `json:"date"`
-	Value float64 `json:"value"`
-	Norm  *int    `json:"norm,omitempty"` // Only in timelinevolraw
+	Date        string       `json:"date"`
+	Value       float64      `json:"value"`
+	Norm        *int         `json:"norm,omitempty"`    // Only in timelinevolraw
+	TopArticles []TopArticle `json:"toparts,omitempty"` // Only in timelinevolinfo
 }
 
 // TimelineRow represents a single row of timeline data after processing
 type TimelineRow struct {
 	DateTime    time.Time
 	Series      map[string]float64
-	AllArticles *int // Only populated for timelinevolraw
+	AllArticles *int         // Only populated for timelinevolraw
+	TopArticles []TopArticle // Only populated for timelinevolinfo
 }
 
 // TimelineResult is the processed timeline data
@@ -61,4 +117,35 @@ type TimelineResult struct {
 	QueryDetails QueryDetails
 	Rows         []TimelineRow
 	SeriesNames  []string
+	Warnings     []RepairWarning // Repairs made to a malformed response
+}
+
+// ToneChart is the result of the tonechart mode: a histogram of how many
+// articles fall into each tone bin, from most negative to most positive
+type ToneChart struct {
+	Bins     []ToneBin
+	Warnings []RepairWarning // Repairs made to a malformed response
+}
+
+// ToneBin is a single bar of the tone histogram
+type ToneBin struct {
+	Bin         int          `json:"bin"`   // Tone value the bin is centred on
+	Count       int          `json:"count"` // Number of articles in the bin
+	TopArticles []TopArticle `json:"toparts"`
+}
+
+// TopArticle is a sample article returned alongside aggregate results,
+// such as a tone bin or a timelinevolinfo time bucket
+type TopArticle struct {
+	URL   string `json:"url"`
+	Title string `json:"title"`
+}
+
+// TotalCount returns the number of articles across all bins
+func (t *ToneChart) TotalCount() int {
+	total := 0
+	for _, bin := range t.Bins {
+		total += bin.Count
+	}
+	return total
 }
@@ -1,8 +1,11 @@
 package gdelt
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	connect "connectrpc.com/connect"
@@ -15,10 +18,16 @@ type Service struct {
 	client *gdeltclient.Client
 }
 
+// responseCacheSize is the number of GDELT responses kept in memory, shared
+// by all users of the service
+const responseCacheSize = 1000
+
 // NewService creates a new GdeltService
 func NewService() *Service {
 	return &Service{
-		client: gdeltclient.NewClient(),
+		client: gdeltclient.NewClient(
+			gdeltclient.WithCache(gdeltclient.NewMemoryCache(responseCacheSize), gdeltclient.DefaultCacheTTL()),
+		),
 	}
 }
 
@@ -34,32 +43,107 @@ func parseDate(s string) (*time.Time, error) {
 	return &t, nil
 }
 
-// SearchArticles searches for articles matching the query
-func (s *Service) SearchArticles(ctx context.Context, req *connect.Request[gdeltv1.SearchArticlesRequest]) (*connect.Response[gdeltv1.SearchArticlesResponse], error) {
-	// Build filters from request
+// buildFilters builds the common query filters shared by all requests.
+// prefix is the path of the message holding the fields, e.g. "timeline.",
+// and is prepended to the field of any validation error.
+func buildFilters(prefix, query, timespan, startDate, endDate string) (*gdeltclient.Filters, error) {
 	filters := &gdeltclient.Filters{
-		Keyword: req.Msg.Query,
+		Keyword: query,
 	}
 
-	if req.Msg.Timespan != "" {
-		filters.Timespan = req.Msg.Timespan
+	if timespan != "" {
+		span, err := gdeltclient.ParseTimespan(timespan)
+		if err != nil {
+			return nil, invalidArgument(prefix+"timespan", err.Error())
+		}
+		filters.Timespan = span
 	}
 
-	if req.Msg.StartDate != "" {
-		startDate, err := parseDate(req.Msg.StartDate)
+	if startDate != "" {
+		start, err := parseDate(startDate)
 		if err != nil {
-			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid start_date format: %w", err))
+			return nil, invalidArgument(prefix+"start_date", err.Error())
 		}
-		filters.StartDate = startDate
+		filters.StartDate = start
 	}
 
-	if req.Msg.EndDate != "" {
-		endDate, err := parseDate(req.Msg.EndDate)
+	if endDate != "" {
+		end, err := parseDate(endDate)
 		if err != nil {
-			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid end_date format: %w", err))
+			return nil, invalidArgument(prefix+"end_date", err.Error())
+		}
+		filters.EndDate = end
+	}
+
+	return filters, nil
+}
+
+// requestFields maps the Filters fields set by the handlers to the request
+// fields they come from, so validation errors point at the caller's input
+var requestFields = map[string]string{
+	"Keyword":         "query",
+	"Timespan":        "timespan",
+	"StartDate":       "start_date",
+	"EndDate":         "end_date",
+	"NumRecords":      "max_records",
+	"Sort":            "sort",
+	"TimelineSmooth":  "timeline_smooth",
+	"DomainExclude":   "exclude.domains",
+	"CountryExclude":  "exclude.countries",
+	"LanguageExclude": "exclude.languages",
+	"ThemeExclude":    "exclude.themes",
+	"Filters":         "queries",
+}
+
+// invalidArgument returns a CodeInvalidArgument error carrying a
+// FieldViolation detail for field
+func invalidArgument(field, reason string) error {
+	cerr := connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid %s: %s", field, reason))
+	if detail, err := connect.NewErrorDetail(&gdeltv1.FieldViolation{Field: field, Reason: reason}); err == nil {
+		cerr.AddDetail(detail)
+	}
+	return cerr
+}
+
+// clientError maps an error from the GDELT client to a Connect error.
+// Filters the client rejects are the caller's fault and are reported
+// against the request field under prefix (see buildFilters). A cancelled or
+// timed-out request keeps its context's code; anything else is reported as
+// internal.
+func clientError(prefix, msg string, err error) error {
+	var verr *gdeltclient.ValidationError
+	if errors.As(err, &verr) {
+		field, ok := requestFields[verr.Field]
+		if !ok {
+			field = verr.Field
 		}
-		filters.EndDate = endDate
+		return invalidArgument(prefix+field, verr.Reason)
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return connect.NewError(connect.CodeCanceled, fmt.Errorf("%s: %w", msg, err))
+	case errors.Is(err, context.DeadlineExceeded):
+		return connect.NewError(connect.CodeDeadlineExceeded, fmt.Errorf("%s: %w", msg, err))
+	}
+	return connect.NewError(connect.CodeInternal, fmt.Errorf("%s: %w", msg, err))
+}
+
+// applyExclusions copies the requested exclusions onto filters
+func applyExclusions(filters *gdeltclient.Filters, exclude *gdeltv1.Exclusions) {
+	filters.DomainExclude = exclude.GetDomains()
+	filters.CountryExclude = exclude.GetCountries()
+	filters.LanguageExclude = exclude.GetLanguages()
+	filters.ThemeExclude = exclude.GetThemes()
+}
+
+// SearchArticles searches for articles matching the query
+func (s *Service) SearchArticles(ctx context.Context, req *connect.Request[gdeltv1.SearchArticlesRequest]) (*connect.Response[gdeltv1.SearchArticlesResponse], error) {
+	// Build filters from request
+	filters, err := buildFilters("", req.Msg.Query, req.Msg.Timespan, req.Msg.StartDate, req.Msg.EndDate)
+	if err != nil {
+		return nil, err
 	}
+	applyExclusions(filters, req.Msg.Exclude)
 
 	if req.Msg.MaxRecords > 0 {
 		filters.NumRecords = int(req.Msg.MaxRecords)
@@ -67,21 +151,33 @@ func (s *Service) SearchArticles(ctx context.Context, req *connect.Request[gdelt
 		filters.NumRecords = 50 // default
 	}
 
+	sort := gdeltclient.SortOrder(req.Msg.Sort)
+	if !sort.IsValid() {
+		return nil, invalidArgument("sort", fmt.Sprintf("unsupported sort order %q", req.Msg.Sort))
+	}
+	filters.Sort = sort
+
 	// Search articles
-	articles, err := s.client.ArticleSearch(filters)
+	articles, err := s.client.ArticleSearchContext(ctx, filters)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to search articles: %w", err))
+		return nil, clientError("", "failed to search articles", err)
 	}
 
 	// Convert to proto response
 	protoArticles := make([]*gdeltv1.Article, len(articles))
 	for i, a := range articles {
 		protoArticles[i] = &gdeltv1.Article{
-			Url:      a.URL,
-			Title:    a.Title,
-			Domain:   a.Domain,
-			Language: a.Language,
-			Seendate: a.SeenDate,
+			Url:           a.URL,
+			Title:         a.Title,
+			Domain:        a.Domain,
+			Language:      a.Language,
+			Seendate:      a.SeenDate,
+			LanguageCode:  a.LanguageCode,
+			SourceCountry: a.SourceCountry,
+			CountryCode:   a.CountryCode,
+		}
+		if !a.Seen.IsZero() {
+			protoArticles[i].SeenTime = a.Seen.Format("2006-01-02T15:04:05Z")
 		}
 	}
 
@@ -90,45 +186,53 @@ func (s *Service) SearchArticles(ctx context.Context, req *connect.Request[gdelt
 	}), nil
 }
 
-// GetTimeline retrieves timeline data for the query
-func (s *Service) GetTimeline(ctx context.Context, req *connect.Request[gdeltv1.GetTimelineRequest]) (*connect.Response[gdeltv1.GetTimelineResponse], error) {
-	mode := req.Msg.Mode
-	if mode == "" {
-		mode = gdeltclient.ModeTimelineVol
-	}
-
-	// Build filters from request
-	filters := &gdeltclient.Filters{
-		Keyword: req.Msg.Query,
+// timelineFilters builds the filters of a timeline query, reporting
+// errors under prefix like buildFilters
+func timelineFilters(prefix, query, timespan, startDate, endDate string, smooth int32, exclude *gdeltv1.Exclusions) (*gdeltclient.Filters, error) {
+	filters, err := buildFilters(prefix, query, timespan, startDate, endDate)
+	if err != nil {
+		return nil, err
 	}
+	applyExclusions(filters, exclude)
 
-	if req.Msg.Timespan != "" {
-		filters.Timespan = req.Msg.Timespan
-	}
+	// Set default NumRecords for timeline
+	filters.NumRecords = 250
 
-	if req.Msg.StartDate != "" {
-		startDate, err := parseDate(req.Msg.StartDate)
-		if err != nil {
-			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid start_date format: %w", err))
+	if smooth != 0 {
+		if smooth < gdeltclient.MinTimelineSmooth || smooth > gdeltclient.MaxTimelineSmooth {
+			return nil, invalidArgument(prefix+"timeline_smooth", fmt.Sprintf("must be between %d and %d", gdeltclient.MinTimelineSmooth, gdeltclient.MaxTimelineSmooth))
 		}
-		filters.StartDate = startDate
+		filters.TimelineSmooth = int(smooth)
 	}
+	return filters, nil
+}
 
-	if req.Msg.EndDate != "" {
-		endDate, err := parseDate(req.Msg.EndDate)
-		if err != nil {
-			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid end_date format: %w", err))
-		}
-		filters.EndDate = endDate
+// timeline runs the timeline query described by req, which is found at
+// prefix in the RPC's request
+func (s *Service) timeline(ctx context.Context, prefix string, req *gdeltv1.GetTimelineRequest) (*gdeltclient.TimelineResult, error) {
+	mode := req.GetMode()
+	if mode == "" {
+		mode = gdeltclient.ModeTimelineVol
 	}
 
-	// Set default NumRecords for timeline
-	filters.NumRecords = 250
+	filters, err := timelineFilters(prefix, req.GetQuery(), req.GetTimespan(), req.GetStartDate(), req.GetEndDate(), req.GetTimelineSmooth(), req.GetExclude())
+	if err != nil {
+		return nil, err
+	}
 
 	// Get timeline data
-	result, err := s.client.TimelineSearch(mode, filters)
+	result, err := s.client.TimelineSearchContext(ctx, mode, filters)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get timeline: %w", err))
+		return nil, clientError(prefix, "failed to get timeline", err)
+	}
+	return result, nil
+}
+
+// GetTimeline retrieves timeline data for the query
+func (s *Service) GetTimeline(ctx context.Context, req *connect.Request[gdeltv1.GetTimelineRequest]) (*connect.Response[gdeltv1.GetTimelineResponse], error) {
+	result, err := s.timeline(ctx, "", req.Msg)
+	if err != nil {
+		return nil, err
 	}
 
 	// Convert to proto response - for now use first series
@@ -141,9 +245,19 @@ func (s *Service) GetTimeline(ctx context.Context, req *connect.Request[gdeltv1.
 			value = row.Series[seriesName]
 		}
 
+		// Sample articles are only present for timelinevolinfo
+		topArticles := make([]*gdeltv1.Article, len(row.TopArticles))
+		for j, a := range row.TopArticles {
+			topArticles[j] = &gdeltv1.Article{
+				Url:   a.URL,
+				Title: a.Title,
+			}
+		}
+
 		points[i] = &gdeltv1.TimelinePoint{
-			Date:  row.DateTime.Format("2006-01-02T15:04:05Z"),
-			Value: value,
+			Date:        row.DateTime.Format("2006-01-02T15:04:05Z"),
+			Value:       value,
+			TopArticles: topArticles,
 		}
 	}
 
@@ -151,3 +265,194 @@ func (s *Service) GetTimeline(ctx context.Context, req *connect.Request[gdeltv1.
 		Points: points,
 	}), nil
 }
+
+// ExportTimeline runs a timeline query and returns it encoded as CSV, JSON
+// Lines or an Arrow IPC stream, for loading into notebooks
+func (s *Service) ExportTimeline(ctx context.Context, req *connect.Request[gdeltv1.ExportTimelineRequest]) (*connect.Response[gdeltv1.ExportTimelineResponse], error) {
+	format := gdeltclient.ExportFormat(req.Msg.Format)
+	if format == "" {
+		format = gdeltclient.ExportCSV
+	}
+	if !format.IsValid() {
+		return nil, invalidArgument("format", fmt.Sprintf("unsupported export format %q (must be one of: csv, jsonl, arrow)", req.Msg.Format))
+	}
+
+	// The query fields are nested under timeline in this request
+	result, err := s.timeline(ctx, "timeline.", req.Msg.GetTimeline())
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := result.Export(&buf, format); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to export timeline: %w", err))
+	}
+
+	mode := req.Msg.GetTimeline().GetMode()
+	if mode == "" {
+		mode = gdeltclient.ModeTimelineVol
+	}
+	return connect.NewResponse(&gdeltv1.ExportTimelineResponse{
+		Data:        buf.Bytes(),
+		ContentType: format.ContentType(),
+		Filename:    mode + "." + string(format),
+	}), nil
+}
+
+// maxCompareQueries bounds the number of queries in one comparison
+const maxCompareQueries = 10
+
+// compareModes are the modes CompareTimelines accepts
+var compareModes = map[string]bool{
+	gdeltclient.ModeTimelineVol:           true,
+	gdeltclient.ModeTimelineVolRaw:        true,
+	gdeltclient.ModeTimelineVolInfo:       true,
+	gdeltclient.ModeTimelineTone:          true,
+	gdeltclient.ModeTimelineLang:          true,
+	gdeltclient.ModeTimelineSourceCountry: true,
+}
+
+// gapFills maps the fill names accepted by CompareTimelines
+var gapFills = map[string]gdeltclient.GapFill{
+	"":         gdeltclient.GapFillZero,
+	"zero":     gdeltclient.GapFillZero,
+	"previous": gdeltclient.GapFillPrevious,
+	"none":     gdeltclient.GapFillNone,
+}
+
+// CompareTimelines runs one timeline query per label over the same window
+// and returns them aligned on a shared time axis
+func (s *Service) CompareTimelines(ctx context.Context, req *connect.Request[gdeltv1.CompareTimelinesRequest]) (*connect.Response[gdeltv1.CompareTimelinesResponse], error) {
+	mode := req.Msg.Mode
+	if mode == "" {
+		mode = gdeltclient.ModeTimelineVol
+	}
+	if !compareModes[mode] {
+		return nil, invalidArgument("mode", fmt.Sprintf("unsupported timeline mode %q", mode))
+	}
+	if n := len(req.Msg.Queries); n == 0 || n > maxCompareQueries {
+		return nil, invalidArgument("queries", fmt.Sprintf("must hold between 1 and %d queries, got %d", maxCompareQueries, n))
+	}
+	fill, ok := gapFills[req.Msg.Fill]
+	if !ok {
+		return nil, invalidArgument("fill", fmt.Sprintf("unsupported fill %q (must be one of: zero, previous, none)", req.Msg.Fill))
+	}
+
+	queries := make([]gdeltclient.LabeledFilters, len(req.Msg.Queries))
+	seen := make(map[string]bool, len(req.Msg.Queries))
+	for i, q := range req.Msg.Queries {
+		labelField := fmt.Sprintf("queries[%d].label", i)
+		switch {
+		case q.Label == "":
+			return nil, invalidArgument(labelField, "must not be empty")
+		case strings.Contains(q.Label, "/"):
+			return nil, invalidArgument(labelField, fmt.Sprintf("label %q must not contain \"/\"", q.Label))
+		case seen[q.Label]:
+			return nil, invalidArgument(labelField, fmt.Sprintf("duplicate label %q", q.Label))
+		}
+		seen[q.Label] = true
+
+		filters, err := timelineFilters("", q.Query, req.Msg.Timespan, req.Msg.StartDate, req.Msg.EndDate, req.Msg.TimelineSmooth, req.Msg.Exclude)
+		if err != nil {
+			return nil, err
+		}
+		// Check each query up front, so a bad one is reported against its
+		// own entry rather than whichever query failed first
+		if _, err := filters.BuildQueryString(); err != nil {
+			var verr *gdeltclient.ValidationError
+			if errors.As(err, &verr) && verr.Field == "Keyword" {
+				return nil, invalidArgument(fmt.Sprintf("queries[%d].query", i), verr.Reason)
+			}
+			return nil, clientError("", "invalid query", err)
+		}
+		queries[i] = gdeltclient.LabeledFilters{Label: q.Label, Filters: filters}
+	}
+
+	result, err := s.client.CompareTimelinesContext(ctx, mode, queries, gdeltclient.CompareOptions{Fill: fill})
+	if err != nil {
+		return nil, clientError("", "failed to compare timelines", err)
+	}
+
+	// Convert to proto response
+	points := make([]*gdeltv1.ComparisonPoint, len(result.Rows))
+	for i, row := range result.Rows {
+		points[i] = &gdeltv1.ComparisonPoint{
+			Date:   row.DateTime.Format("2006-01-02T15:04:05Z"),
+			Values: row.Series,
+		}
+	}
+
+	return connect.NewResponse(&gdeltv1.CompareTimelinesResponse{
+		Series: result.SeriesNames,
+		Points: points,
+	}), nil
+}
+
+// GetWordCloud retrieves image tag term frequencies for the query
+func (s *Service) GetWordCloud(ctx context.Context, req *connect.Request[gdeltv1.GetWordCloudRequest]) (*connect.Response[gdeltv1.GetWordCloudResponse], error) {
+	mode := req.Msg.Mode
+	if mode == "" {
+		mode = gdeltclient.ModeWordCloudImageTags
+	}
+	if mode != gdeltclient.ModeWordCloudImageTags && mode != gdeltclient.ModeWordCloudImageWebTags {
+		return nil, invalidArgument("mode", fmt.Sprintf("unsupported word cloud mode %q", mode))
+	}
+
+	filters, err := buildFilters("", req.Msg.Query, req.Msg.Timespan, req.Msg.StartDate, req.Msg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	applyExclusions(filters, req.Msg.Exclude)
+
+	cloud, err := s.client.WordCloudSearchContext(ctx, mode, filters)
+	if err != nil {
+		return nil, clientError("", "failed to get word cloud", err)
+	}
+
+	// Convert to proto response
+	protoTerms := make([]*gdeltv1.TermWeight, len(cloud.Terms))
+	for i, t := range cloud.Terms {
+		protoTerms[i] = &gdeltv1.TermWeight{
+			Term:   t.Term,
+			Weight: t.Weight,
+		}
+	}
+
+	return connect.NewResponse(&gdeltv1.GetWordCloudResponse{
+		Terms: protoTerms,
+	}), nil
+}
+
+// Theme autocomplete limits
+const (
+	defaultThemeLimit = 20
+	maxThemeLimit     = 100
+)
+
+// SearchThemes suggests GKG themes matching a partial name or description,
+// for autocompleting theme filters
+func (s *Service) SearchThemes(ctx context.Context, req *connect.Request[gdeltv1.SearchThemesRequest]) (*connect.Response[gdeltv1.SearchThemesResponse], error) {
+	limit := int(req.Msg.Limit)
+	if limit < 0 || limit > maxThemeLimit {
+		return nil, invalidArgument("limit", fmt.Sprintf("must be between 0 and %d, got %d", maxThemeLimit, limit))
+	}
+	if limit == 0 {
+		limit = defaultThemeLimit
+	}
+
+	themes := gdeltclient.SearchThemes(req.Msg.Query, limit)
+
+	// Convert to proto response
+	protoThemes := make([]*gdeltv1.Theme, len(themes))
+	for i, t := range themes {
+		protoThemes[i] = &gdeltv1.Theme{
+			Name:        t.Name,
+			Description: t.Description,
+			Family:      t.IsFamily(),
+		}
+	}
+
+	return connect.NewResponse(&gdeltv1.SearchThemesResponse{
+		Themes: protoThemes,
+	}), nil
+}
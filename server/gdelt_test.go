@@ -0,0 +1,80 @@
+package gdelt
+
+import (
+	"context"
+	"testing"
+
+	connect "connectrpc.com/connect"
+	gdeltv1 "imply/server/gen/gdelt/v1"
+)
+
+// fieldViolation returns the FieldViolation detail of a Connect error
+func fieldViolation(t *testing.T, err error) *gdeltv1.FieldViolation {
+	t.Helper()
+	cerr, ok := err.(*connect.Error)
+	if !ok {
+		t.Fatalf("error = %v, want a *connect.Error", err)
+	}
+	for _, detail := range cerr.Details() {
+		msg, err := detail.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if violation, ok := msg.(*gdeltv1.FieldViolation); ok {
+			return violation
+		}
+	}
+	t.Fatalf("error %v has no FieldViolation detail", err)
+	return nil
+}
+
+func TestCompareTimelinesLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		queries []*gdeltv1.LabeledQuery
+		field   string
+	}{
+		{
+			name: "duplicate",
+			queries: []*gdeltv1.LabeledQuery{
+				{Label: "solar", Query: "solar"},
+				{Label: "wind", Query: "wind"},
+				{Label: "solar", Query: "solar power"},
+			},
+			field: "queries[2].label",
+		},
+		{
+			name: "empty",
+			queries: []*gdeltv1.LabeledQuery{
+				{Label: "solar", Query: "solar"},
+				{Query: "wind"},
+			},
+			field: "queries[1].label",
+		},
+		{
+			name: "slash",
+			queries: []*gdeltv1.LabeledQuery{
+				{Label: "solar", Query: "solar"},
+				{Label: "wind/tidal", Query: "wind"},
+			},
+			field: "queries[1].label",
+		},
+	}
+
+	// Labels are checked before any request reaches GDELT
+	s := NewService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.CompareTimelines(context.Background(), connect.NewRequest(&gdeltv1.CompareTimelinesRequest{
+				Queries:  tt.queries,
+				Timespan: "24h",
+			}))
+			if code := connect.CodeOf(err); code != connect.CodeInvalidArgument {
+				t.Fatalf("CompareTimelines() code = %v (%v), want InvalidArgument", code, err)
+			}
+			if violation := fieldViolation(t, err); violation.Field != tt.field {
+				t.Errorf("field = %q, want %q", violation.Field, tt.field)
+			}
+		})
+	}
+}
@@ -28,6 +28,8 @@ type SearchArticlesRequest struct {
 	StartDate     string                 `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
 	EndDate       string                 `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
 	MaxRecords    int32                  `protobuf:"varint,5,opt,name=max_records,json=maxRecords,proto3" json:"max_records,omitempty"`
+	Sort          string                 `protobuf:"bytes,6,opt,name=sort,proto3" json:"sort,omitempty"`
+	Exclude       *Exclusions            `protobuf:"bytes,7,opt,name=exclude,proto3" json:"exclude,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -97,6 +99,88 @@ func (x *SearchArticlesRequest) GetMaxRecords() int32 {
 	return 0
 }
 
+func (x *SearchArticlesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *SearchArticlesRequest) GetExclude() *Exclusions {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+type Exclusions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domains       []string               `protobuf:"bytes,1,rep,name=domains,proto3" json:"domains,omitempty"`
+	Countries     []string               `protobuf:"bytes,2,rep,name=countries,proto3" json:"countries,omitempty"`
+	Languages     []string               `protobuf:"bytes,3,rep,name=languages,proto3" json:"languages,omitempty"`
+	Themes        []string               `protobuf:"bytes,4,rep,name=themes,proto3" json:"themes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Exclusions) Reset() {
+	*x = Exclusions{}
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Exclusions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Exclusions) ProtoMessage() {}
+
+func (x *Exclusions) ProtoReflect() protoreflect.Message {
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Exclusions.ProtoReflect.Descriptor instead.
+func (*Exclusions) Descriptor() ([]byte, []int) {
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Exclusions) GetDomains() []string {
+	if x != nil {
+		return x.Domains
+	}
+	return nil
+}
+
+func (x *Exclusions) GetCountries() []string {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *Exclusions) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *Exclusions) GetThemes() []string {
+	if x != nil {
+		return x.Themes
+	}
+	return nil
+}
+
 type Article struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
@@ -104,13 +188,17 @@ type Article struct {
 	Domain        string                 `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
 	Language      string                 `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`
 	Seendate      string                 `protobuf:"bytes,5,opt,name=seendate,proto3" json:"seendate,omitempty"`
+	SeenTime      string                 `protobuf:"bytes,6,opt,name=seen_time,json=seenTime,proto3" json:"seen_time,omitempty"`
+	LanguageCode  string                 `protobuf:"bytes,7,opt,name=language_code,json=languageCode,proto3" json:"language_code,omitempty"`
+	SourceCountry string                 `protobuf:"bytes,8,opt,name=source_country,json=sourceCountry,proto3" json:"source_country,omitempty"`
+	CountryCode   string                 `protobuf:"bytes,9,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Article) Reset() {
 	*x = Article{}
-	mi := &file_gdelt_v1_gdelt_proto_msgTypes[1]
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -122,7 +210,7 @@ func (x *Article) String() string {
 func (*Article) ProtoMessage() {}
 
 func (x *Article) ProtoReflect() protoreflect.Message {
-	mi := &file_gdelt_v1_gdelt_proto_msgTypes[1]
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -135,7 +223,7 @@ func (x *Article) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Article.ProtoReflect.Descriptor instead.
 func (*Article) Descriptor() ([]byte, []int) {
-	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{1}
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Article) GetUrl() string {
@@ -173,6 +261,34 @@ func (x *Article) GetSeendate() string {
 	return ""
 }
 
+func (x *Article) GetSeenTime() string {
+	if x != nil {
+		return x.SeenTime
+	}
+	return ""
+}
+
+func (x *Article) GetLanguageCode() string {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return ""
+}
+
+func (x *Article) GetSourceCountry() string {
+	if x != nil {
+		return x.SourceCountry
+	}
+	return ""
+}
+
+func (x *Article) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
 type SearchArticlesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Articles      []*Article             `protobuf:"bytes,1,rep,name=articles,proto3" json:"articles,omitempty"`
@@ -182,7 +298,7 @@ type SearchArticlesResponse struct {
 
 func (x *SearchArticlesResponse) Reset() {
 	*x = SearchArticlesResponse{}
-	mi := &file_gdelt_v1_gdelt_proto_msgTypes[2]
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -194,7 +310,7 @@ func (x *SearchArticlesResponse) String() string {
 func (*SearchArticlesResponse) ProtoMessage() {}
 
 func (x *SearchArticlesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_gdelt_v1_gdelt_proto_msgTypes[2]
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -207,7 +323,7 @@ func (x *SearchArticlesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchArticlesResponse.ProtoReflect.Descriptor instead.
 func (*SearchArticlesResponse) Descriptor() ([]byte, []int) {
-	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{2}
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *SearchArticlesResponse) GetArticles() []*Article {
@@ -218,19 +334,21 @@ func (x *SearchArticlesResponse) GetArticles() []*Article {
 }
 
 type GetTimelineRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	Mode          string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
-	Timespan      string                 `protobuf:"bytes,3,opt,name=timespan,proto3" json:"timespan,omitempty"`
-	StartDate     string                 `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
-	EndDate       string                 `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Query          string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Mode           string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Timespan       string                 `protobuf:"bytes,3,opt,name=timespan,proto3" json:"timespan,omitempty"`
+	StartDate      string                 `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate        string                 `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	TimelineSmooth int32                  `protobuf:"varint,6,opt,name=timeline_smooth,json=timelineSmooth,proto3" json:"timeline_smooth,omitempty"`
+	Exclude        *Exclusions            `protobuf:"bytes,7,opt,name=exclude,proto3" json:"exclude,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GetTimelineRequest) Reset() {
 	*x = GetTimelineRequest{}
-	mi := &file_gdelt_v1_gdelt_proto_msgTypes[3]
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -242,7 +360,7 @@ func (x *GetTimelineRequest) String() string {
 func (*GetTimelineRequest) ProtoMessage() {}
 
 func (x *GetTimelineRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_gdelt_v1_gdelt_proto_msgTypes[3]
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -255,7 +373,7 @@ func (x *GetTimelineRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTimelineRequest.ProtoReflect.Descriptor instead.
 func (*GetTimelineRequest) Descriptor() ([]byte, []int) {
-	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{3}
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetTimelineRequest) GetQuery() string {
@@ -293,17 +411,32 @@ func (x *GetTimelineRequest) GetEndDate() string {
 	return ""
 }
 
+func (x *GetTimelineRequest) GetTimelineSmooth() int32 {
+	if x != nil {
+		return x.TimelineSmooth
+	}
+	return 0
+}
+
+func (x *GetTimelineRequest) GetExclude() *Exclusions {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
 type TimelinePoint struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
 	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	TopArticles   []*Article             `protobuf:"bytes,3,rep,name=top_articles,json=topArticles,proto3" json:"top_articles,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TimelinePoint) Reset() {
 	*x = TimelinePoint{}
-	mi := &file_gdelt_v1_gdelt_proto_msgTypes[4]
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -315,7 +448,7 @@ func (x *TimelinePoint) String() string {
 func (*TimelinePoint) ProtoMessage() {}
 
 func (x *TimelinePoint) ProtoReflect() protoreflect.Message {
-	mi := &file_gdelt_v1_gdelt_proto_msgTypes[4]
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -328,7 +461,7 @@ func (x *TimelinePoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TimelinePoint.ProtoReflect.Descriptor instead.
 func (*TimelinePoint) Descriptor() ([]byte, []int) {
-	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{4}
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *TimelinePoint) GetDate() string {
@@ -345,6 +478,13 @@ func (x *TimelinePoint) GetValue() float64 {
 	return 0
 }
 
+func (x *TimelinePoint) GetTopArticles() []*Article {
+	if x != nil {
+		return x.TopArticles
+	}
+	return nil
+}
+
 type GetTimelineResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Points        []*TimelinePoint       `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
@@ -354,7 +494,7 @@ type GetTimelineResponse struct {
 
 func (x *GetTimelineResponse) Reset() {
 	*x = GetTimelineResponse{}
-	mi := &file_gdelt_v1_gdelt_proto_msgTypes[5]
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -366,7 +506,7 @@ func (x *GetTimelineResponse) String() string {
 func (*GetTimelineResponse) ProtoMessage() {}
 
 func (x *GetTimelineResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_gdelt_v1_gdelt_proto_msgTypes[5]
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -379,7 +519,7 @@ func (x *GetTimelineResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTimelineResponse.ProtoReflect.Descriptor instead.
 func (*GetTimelineResponse) Descriptor() ([]byte, []int) {
-	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{5}
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetTimelineResponse) GetPoints() []*TimelinePoint {
@@ -389,42 +529,872 @@ func (x *GetTimelineResponse) GetPoints() []*TimelinePoint {
 	return nil
 }
 
-var File_gdelt_v1_gdelt_proto protoreflect.FileDescriptor
+type ExportTimelineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timeline      *GetTimelineRequest    `protobuf:"bytes,1,opt,name=timeline,proto3" json:"timeline,omitempty"`
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_gdelt_v1_gdelt_proto_rawDesc = "" +
-	"\n" +
-	"\x14gdelt/v1/gdelt.proto\x12\bgdelt.v1\"\xa4\x01\n" +
-	"\x15SearchArticlesRequest\x12\x14\n" +
-	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1a\n" +
-	"\btimespan\x18\x02 \x01(\tR\btimespan\x12\x1d\n" +
-	"\n" +
-	"start_date\x18\x03 \x01(\tR\tstartDate\x12\x19\n" +
-	"\bend_date\x18\x04 \x01(\tR\aendDate\x12\x1f\n" +
-	"\vmax_records\x18\x05 \x01(\x05R\n" +
-	"maxRecords\"\x81\x01\n" +
-	"\aArticle\x12\x10\n" +
-	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
-	"\x06domain\x18\x03 \x01(\tR\x06domain\x12\x1a\n" +
-	"\blanguage\x18\x04 \x01(\tR\blanguage\x12\x1a\n" +
-	"\bseendate\x18\x05 \x01(\tR\bseendate\"G\n" +
-	"\x16SearchArticlesResponse\x12-\n" +
-	"\barticles\x18\x01 \x03(\v2\x11.gdelt.v1.ArticleR\barticles\"\x94\x01\n" +
-	"\x12GetTimelineRequest\x12\x14\n" +
-	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
-	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x1a\n" +
-	"\btimespan\x18\x03 \x01(\tR\btimespan\x12\x1d\n" +
-	"\n" +
-	"start_date\x18\x04 \x01(\tR\tstartDate\x12\x19\n" +
-	"\bend_date\x18\x05 \x01(\tR\aendDate\"9\n" +
-	"\rTimelinePoint\x12\x12\n" +
-	"\x04date\x18\x01 \x01(\tR\x04date\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x01R\x05value\"F\n" +
-	"\x13GetTimelineResponse\x12/\n" +
-	"\x06points\x18\x01 \x03(\v2\x17.gdelt.v1.TimelinePointR\x06points2\xaf\x01\n" +
+func (x *ExportTimelineRequest) Reset() {
+	*x = ExportTimelineRequest{}
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTimelineRequest) ProtoMessage() {}
+
+func (x *ExportTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTimelineRequest.ProtoReflect.Descriptor instead.
+func (*ExportTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExportTimelineRequest) GetTimeline() *GetTimelineRequest {
+	if x != nil {
+		return x.Timeline
+	}
+	return nil
+}
+
+func (x *ExportTimelineRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type ExportTimelineResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTimelineResponse) Reset() {
+	*x = ExportTimelineResponse{}
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTimelineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTimelineResponse) ProtoMessage() {}
+
+func (x *ExportTimelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTimelineResponse.ProtoReflect.Descriptor instead.
+func (*ExportTimelineResponse) Descriptor() ([]byte, []int) {
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ExportTimelineResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExportTimelineResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportTimelineResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+type LabeledQuery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Label         string                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LabeledQuery) Reset() {
+	*x = LabeledQuery{}
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LabeledQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabeledQuery) ProtoMessage() {}
+
+func (x *LabeledQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabeledQuery.ProtoReflect.Descriptor instead.
+func (*LabeledQuery) Descriptor() ([]byte, []int) {
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LabeledQuery) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *LabeledQuery) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+type CompareTimelinesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Queries        []*LabeledQuery        `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	Mode           string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Timespan       string                 `protobuf:"bytes,3,opt,name=timespan,proto3" json:"timespan,omitempty"`
+	StartDate      string                 `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate        string                 `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	TimelineSmooth int32                  `protobuf:"varint,6,opt,name=timeline_smooth,json=timelineSmooth,proto3" json:"timeline_smooth,omitempty"`
+	Exclude        *Exclusions            `protobuf:"bytes,7,opt,name=exclude,proto3" json:"exclude,omitempty"`
+	Fill           string                 `protobuf:"bytes,8,opt,name=fill,proto3" json:"fill,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CompareTimelinesRequest) Reset() {
+	*x = CompareTimelinesRequest{}
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareTimelinesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareTimelinesRequest) ProtoMessage() {}
+
+func (x *CompareTimelinesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareTimelinesRequest.ProtoReflect.Descriptor instead.
+func (*CompareTimelinesRequest) Descriptor() ([]byte, []int) {
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CompareTimelinesRequest) GetQueries() []*LabeledQuery {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+func (x *CompareTimelinesRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *CompareTimelinesRequest) GetTimespan() string {
+	if x != nil {
+		return x.Timespan
+	}
+	return ""
+}
+
+func (x *CompareTimelinesRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *CompareTimelinesRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *CompareTimelinesRequest) GetTimelineSmooth() int32 {
+	if x != nil {
+		return x.TimelineSmooth
+	}
+	return 0
+}
+
+func (x *CompareTimelinesRequest) GetExclude() *Exclusions {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+func (x *CompareTimelinesRequest) GetFill() string {
+	if x != nil {
+		return x.Fill
+	}
+	return ""
+}
+
+type ComparisonPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Values        map[string]float64     `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComparisonPoint) Reset() {
+	*x = ComparisonPoint{}
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComparisonPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComparisonPoint) ProtoMessage() {}
+
+func (x *ComparisonPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComparisonPoint.ProtoReflect.Descriptor instead.
+func (*ComparisonPoint) Descriptor() ([]byte, []int) {
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ComparisonPoint) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *ComparisonPoint) GetValues() map[string]float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type CompareTimelinesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Series        []string               `protobuf:"bytes,1,rep,name=series,proto3" json:"series,omitempty"`
+	Points        []*ComparisonPoint     `protobuf:"bytes,2,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareTimelinesResponse) Reset() {
+	*x = CompareTimelinesResponse{}
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareTimelinesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareTimelinesResponse) ProtoMessage() {}
+
+func (x *CompareTimelinesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareTimelinesResponse.ProtoReflect.Descriptor instead.
+func (*CompareTimelinesResponse) Descriptor() ([]byte, []int) {
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CompareTimelinesResponse) GetSeries() []string {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+func (x *CompareTimelinesResponse) GetPoints() []*ComparisonPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type GetWordCloudRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Mode          string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Timespan      string                 `protobuf:"bytes,3,opt,name=timespan,proto3" json:"timespan,omitempty"`
+	StartDate     string                 `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       string                 `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Exclude       *Exclusions            `protobuf:"bytes,6,opt,name=exclude,proto3" json:"exclude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWordCloudRequest) Reset() {
+	*x = GetWordCloudRequest{}
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWordCloudRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWordCloudRequest) ProtoMessage() {}
+
+func (x *GetWordCloudRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWordCloudRequest.ProtoReflect.Descriptor instead.
+func (*GetWordCloudRequest) Descriptor() ([]byte, []int) {
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetWordCloudRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *GetWordCloudRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *GetWordCloudRequest) GetTimespan() string {
+	if x != nil {
+		return x.Timespan
+	}
+	return ""
+}
+
+func (x *GetWordCloudRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *GetWordCloudRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *GetWordCloudRequest) GetExclude() *Exclusions {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+type TermWeight struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          string                 `protobuf:"bytes,1,opt,name=term,proto3" json:"term,omitempty"`
+	Weight        float64                `protobuf:"fixed64,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TermWeight) Reset() {
+	*x = TermWeight{}
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TermWeight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TermWeight) ProtoMessage() {}
+
+func (x *TermWeight) ProtoReflect() protoreflect.Message {
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TermWeight.ProtoReflect.Descriptor instead.
+func (*TermWeight) Descriptor() ([]byte, []int) {
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TermWeight) GetTerm() string {
+	if x != nil {
+		return x.Term
+	}
+	return ""
+}
+
+func (x *TermWeight) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type GetWordCloudResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Terms         []*TermWeight          `protobuf:"bytes,1,rep,name=terms,proto3" json:"terms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWordCloudResponse) Reset() {
+	*x = GetWordCloudResponse{}
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWordCloudResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWordCloudResponse) ProtoMessage() {}
+
+func (x *GetWordCloudResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWordCloudResponse.ProtoReflect.Descriptor instead.
+func (*GetWordCloudResponse) Descriptor() ([]byte, []int) {
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetWordCloudResponse) GetTerms() []*TermWeight {
+	if x != nil {
+		return x.Terms
+	}
+	return nil
+}
+
+type SearchThemesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchThemesRequest) Reset() {
+	*x = SearchThemesRequest{}
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchThemesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchThemesRequest) ProtoMessage() {}
+
+func (x *SearchThemesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchThemesRequest.ProtoReflect.Descriptor instead.
+func (*SearchThemesRequest) Descriptor() ([]byte, []int) {
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SearchThemesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchThemesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Theme struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Family        bool                   `protobuf:"varint,3,opt,name=family,proto3" json:"family,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Theme) Reset() {
+	*x = Theme{}
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Theme) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Theme) ProtoMessage() {}
+
+func (x *Theme) ProtoReflect() protoreflect.Message {
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Theme.ProtoReflect.Descriptor instead.
+func (*Theme) Descriptor() ([]byte, []int) {
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Theme) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Theme) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Theme) GetFamily() bool {
+	if x != nil {
+		return x.Family
+	}
+	return false
+}
+
+type SearchThemesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Themes        []*Theme               `protobuf:"bytes,1,rep,name=themes,proto3" json:"themes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchThemesResponse) Reset() {
+	*x = SearchThemesResponse{}
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchThemesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchThemesResponse) ProtoMessage() {}
+
+func (x *SearchThemesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchThemesResponse.ProtoReflect.Descriptor instead.
+func (*SearchThemesResponse) Descriptor() ([]byte, []int) {
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SearchThemesResponse) GetThemes() []*Theme {
+	if x != nil {
+		return x.Themes
+	}
+	return nil
+}
+
+type FieldViolation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldViolation) Reset() {
+	*x = FieldViolation{}
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldViolation) ProtoMessage() {}
+
+func (x *FieldViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_gdelt_v1_gdelt_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldViolation.ProtoReflect.Descriptor instead.
+func (*FieldViolation) Descriptor() ([]byte, []int) {
+	return file_gdelt_v1_gdelt_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FieldViolation) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldViolation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+var File_gdelt_v1_gdelt_proto protoreflect.FileDescriptor
+
+const file_gdelt_v1_gdelt_proto_rawDesc = "" +
+	"\n" +
+	"\x14gdelt/v1/gdelt.proto\x12\bgdelt.v1\"\xe8\x01\n" +
+	"\x15SearchArticlesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1a\n" +
+	"\btimespan\x18\x02 \x01(\tR\btimespan\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x04 \x01(\tR\aendDate\x12\x1f\n" +
+	"\vmax_records\x18\x05 \x01(\x05R\n" +
+	"maxRecords\x12\x12\n" +
+	"\x04sort\x18\x06 \x01(\tR\x04sort\x12.\n" +
+	"\aexclude\x18\a \x01(\v2\x14.gdelt.v1.ExclusionsR\aexclude\"z\n" +
+	"\n" +
+	"Exclusions\x12\x18\n" +
+	"\adomains\x18\x01 \x03(\tR\adomains\x12\x1c\n" +
+	"\tcountries\x18\x02 \x03(\tR\tcountries\x12\x1c\n" +
+	"\tlanguages\x18\x03 \x03(\tR\tlanguages\x12\x16\n" +
+	"\x06themes\x18\x04 \x03(\tR\x06themes\"\x8d\x02\n" +
+	"\aArticle\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06domain\x18\x03 \x01(\tR\x06domain\x12\x1a\n" +
+	"\blanguage\x18\x04 \x01(\tR\blanguage\x12\x1a\n" +
+	"\bseendate\x18\x05 \x01(\tR\bseendate\x12\x1b\n" +
+	"\tseen_time\x18\x06 \x01(\tR\bseenTime\x12#\n" +
+	"\rlanguage_code\x18\a \x01(\tR\flanguageCode\x12%\n" +
+	"\x0esource_country\x18\b \x01(\tR\rsourceCountry\x12!\n" +
+	"\fcountry_code\x18\t \x01(\tR\vcountryCode\"G\n" +
+	"\x16SearchArticlesResponse\x12-\n" +
+	"\barticles\x18\x01 \x03(\v2\x11.gdelt.v1.ArticleR\barticles\"\xed\x01\n" +
+	"\x12GetTimelineRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x1a\n" +
+	"\btimespan\x18\x03 \x01(\tR\btimespan\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x04 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x05 \x01(\tR\aendDate\x12'\n" +
+	"\x0ftimeline_smooth\x18\x06 \x01(\x05R\x0etimelineSmooth\x12.\n" +
+	"\aexclude\x18\a \x01(\v2\x14.gdelt.v1.ExclusionsR\aexclude\"o\n" +
+	"\rTimelinePoint\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\x124\n" +
+	"\ftop_articles\x18\x03 \x03(\v2\x11.gdelt.v1.ArticleR\vtopArticles\"F\n" +
+	"\x13GetTimelineResponse\x12/\n" +
+	"\x06points\x18\x01 \x03(\v2\x17.gdelt.v1.TimelinePointR\x06points\"i\n" +
+	"\x15ExportTimelineRequest\x128\n" +
+	"\btimeline\x18\x01 \x01(\v2\x1c.gdelt.v1.GetTimelineRequestR\btimeline\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\"k\n" +
+	"\x16ExportTimelineResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\":\n" +
+	"\fLabeledQuery\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\"\xa2\x02\n" +
+	"\x17CompareTimelinesRequest\x120\n" +
+	"\aqueries\x18\x01 \x03(\v2\x16.gdelt.v1.LabeledQueryR\aqueries\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x1a\n" +
+	"\btimespan\x18\x03 \x01(\tR\btimespan\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x04 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x05 \x01(\tR\aendDate\x12'\n" +
+	"\x0ftimeline_smooth\x18\x06 \x01(\x05R\x0etimelineSmooth\x12.\n" +
+	"\aexclude\x18\a \x01(\v2\x14.gdelt.v1.ExclusionsR\aexclude\x12\x12\n" +
+	"\x04fill\x18\b \x01(\tR\x04fill\"\x9f\x01\n" +
+	"\x0fComparisonPoint\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12=\n" +
+	"\x06values\x18\x02 \x03(\v2%.gdelt.v1.ComparisonPoint.ValuesEntryR\x06values\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"e\n" +
+	"\x18CompareTimelinesResponse\x12\x16\n" +
+	"\x06series\x18\x01 \x03(\tR\x06series\x121\n" +
+	"\x06points\x18\x02 \x03(\v2\x19.gdelt.v1.ComparisonPointR\x06points\"\xc5\x01\n" +
+	"\x13GetWordCloudRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x1a\n" +
+	"\btimespan\x18\x03 \x01(\tR\btimespan\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x04 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x05 \x01(\tR\aendDate\x12.\n" +
+	"\aexclude\x18\x06 \x01(\v2\x14.gdelt.v1.ExclusionsR\aexclude\"8\n" +
+	"\n" +
+	"TermWeight\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\tR\x04term\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x01R\x06weight\"B\n" +
+	"\x14GetWordCloudResponse\x12*\n" +
+	"\x05terms\x18\x01 \x03(\v2\x14.gdelt.v1.TermWeightR\x05terms\"A\n" +
+	"\x13SearchThemesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"U\n" +
+	"\x05Theme\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06family\x18\x03 \x01(\bR\x06family\"?\n" +
+	"\x14SearchThemesResponse\x12'\n" +
+	"\x06themes\x18\x01 \x03(\v2\x0f.gdelt.v1.ThemeR\x06themes\">\n" +
+	"\x0eFieldViolation\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason2\xfd\x03\n" +
 	"\fGdeltService\x12S\n" +
 	"\x0eSearchArticles\x12\x1f.gdelt.v1.SearchArticlesRequest\x1a .gdelt.v1.SearchArticlesResponse\x12J\n" +
-	"\vGetTimeline\x12\x1c.gdelt.v1.GetTimelineRequest\x1a\x1d.gdelt.v1.GetTimelineResponseB#Z!imply/server/gen/gdelt/v1;gdeltv1b\x06proto3"
+	"\vGetTimeline\x12\x1c.gdelt.v1.GetTimelineRequest\x1a\x1d.gdelt.v1.GetTimelineResponse\x12M\n" +
+	"\fGetWordCloud\x12\x1d.gdelt.v1.GetWordCloudRequest\x1a\x1e.gdelt.v1.GetWordCloudResponse\x12M\n" +
+	"\fSearchThemes\x12\x1d.gdelt.v1.SearchThemesRequest\x1a\x1e.gdelt.v1.SearchThemesResponse\x12S\n" +
+	"\x0eExportTimeline\x12\x1f.gdelt.v1.ExportTimelineRequest\x1a .gdelt.v1.ExportTimelineResponse\x12Y\n" +
+	"\x10CompareTimelines\x12!.gdelt.v1.CompareTimelinesRequest\x1a\".gdelt.v1.CompareTimelinesResponseB#Z!imply/server/gen/gdelt/v1;gdeltv1b\x06proto3"
 
 var (
 	file_gdelt_v1_gdelt_proto_rawDescOnce sync.Once
@@ -438,27 +1408,61 @@ func file_gdelt_v1_gdelt_proto_rawDescGZIP() []byte {
 	return file_gdelt_v1_gdelt_proto_rawDescData
 }
 
-var file_gdelt_v1_gdelt_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_gdelt_v1_gdelt_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_gdelt_v1_gdelt_proto_goTypes = []any{
-	(*SearchArticlesRequest)(nil),  // 0: gdelt.v1.SearchArticlesRequest
-	(*Article)(nil),                // 1: gdelt.v1.Article
-	(*SearchArticlesResponse)(nil), // 2: gdelt.v1.SearchArticlesResponse
-	(*GetTimelineRequest)(nil),     // 3: gdelt.v1.GetTimelineRequest
-	(*TimelinePoint)(nil),          // 4: gdelt.v1.TimelinePoint
-	(*GetTimelineResponse)(nil),    // 5: gdelt.v1.GetTimelineResponse
+	(*SearchArticlesRequest)(nil),    // 0: gdelt.v1.SearchArticlesRequest
+	(*Exclusions)(nil),               // 1: gdelt.v1.Exclusions
+	(*Article)(nil),                  // 2: gdelt.v1.Article
+	(*SearchArticlesResponse)(nil),   // 3: gdelt.v1.SearchArticlesResponse
+	(*GetTimelineRequest)(nil),       // 4: gdelt.v1.GetTimelineRequest
+	(*TimelinePoint)(nil),            // 5: gdelt.v1.TimelinePoint
+	(*GetTimelineResponse)(nil),      // 6: gdelt.v1.GetTimelineResponse
+	(*ExportTimelineRequest)(nil),    // 7: gdelt.v1.ExportTimelineRequest
+	(*ExportTimelineResponse)(nil),   // 8: gdelt.v1.ExportTimelineResponse
+	(*LabeledQuery)(nil),             // 9: gdelt.v1.LabeledQuery
+	(*CompareTimelinesRequest)(nil),  // 10: gdelt.v1.CompareTimelinesRequest
+	(*ComparisonPoint)(nil),          // 11: gdelt.v1.ComparisonPoint
+	(*CompareTimelinesResponse)(nil), // 12: gdelt.v1.CompareTimelinesResponse
+	(*GetWordCloudRequest)(nil),      // 13: gdelt.v1.GetWordCloudRequest
+	(*TermWeight)(nil),               // 14: gdelt.v1.TermWeight
+	(*GetWordCloudResponse)(nil),     // 15: gdelt.v1.GetWordCloudResponse
+	(*SearchThemesRequest)(nil),      // 16: gdelt.v1.SearchThemesRequest
+	(*Theme)(nil),                    // 17: gdelt.v1.Theme
+	(*SearchThemesResponse)(nil),     // 18: gdelt.v1.SearchThemesResponse
+	(*FieldViolation)(nil),           // 19: gdelt.v1.FieldViolation
+	nil,                              // 20: gdelt.v1.ComparisonPoint.ValuesEntry
 }
 var file_gdelt_v1_gdelt_proto_depIdxs = []int32{
-	1, // 0: gdelt.v1.SearchArticlesResponse.articles:type_name -> gdelt.v1.Article
-	4, // 1: gdelt.v1.GetTimelineResponse.points:type_name -> gdelt.v1.TimelinePoint
-	0, // 2: gdelt.v1.GdeltService.SearchArticles:input_type -> gdelt.v1.SearchArticlesRequest
-	3, // 3: gdelt.v1.GdeltService.GetTimeline:input_type -> gdelt.v1.GetTimelineRequest
-	2, // 4: gdelt.v1.GdeltService.SearchArticles:output_type -> gdelt.v1.SearchArticlesResponse
-	5, // 5: gdelt.v1.GdeltService.GetTimeline:output_type -> gdelt.v1.GetTimelineResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	1,  // 0: gdelt.v1.SearchArticlesRequest.exclude:type_name -> gdelt.v1.Exclusions
+	2,  // 1: gdelt.v1.SearchArticlesResponse.articles:type_name -> gdelt.v1.Article
+	1,  // 2: gdelt.v1.GetTimelineRequest.exclude:type_name -> gdelt.v1.Exclusions
+	2,  // 3: gdelt.v1.TimelinePoint.top_articles:type_name -> gdelt.v1.Article
+	5,  // 4: gdelt.v1.GetTimelineResponse.points:type_name -> gdelt.v1.TimelinePoint
+	4,  // 5: gdelt.v1.ExportTimelineRequest.timeline:type_name -> gdelt.v1.GetTimelineRequest
+	9,  // 6: gdelt.v1.CompareTimelinesRequest.queries:type_name -> gdelt.v1.LabeledQuery
+	1,  // 7: gdelt.v1.CompareTimelinesRequest.exclude:type_name -> gdelt.v1.Exclusions
+	20, // 8: gdelt.v1.ComparisonPoint.values:type_name -> gdelt.v1.ComparisonPoint.ValuesEntry
+	11, // 9: gdelt.v1.CompareTimelinesResponse.points:type_name -> gdelt.v1.ComparisonPoint
+	1,  // 10: gdelt.v1.GetWordCloudRequest.exclude:type_name -> gdelt.v1.Exclusions
+	14, // 11: gdelt.v1.GetWordCloudResponse.terms:type_name -> gdelt.v1.TermWeight
+	17, // 12: gdelt.v1.SearchThemesResponse.themes:type_name -> gdelt.v1.Theme
+	0,  // 13: gdelt.v1.GdeltService.SearchArticles:input_type -> gdelt.v1.SearchArticlesRequest
+	4,  // 14: gdelt.v1.GdeltService.GetTimeline:input_type -> gdelt.v1.GetTimelineRequest
+	13, // 15: gdelt.v1.GdeltService.GetWordCloud:input_type -> gdelt.v1.GetWordCloudRequest
+	16, // 16: gdelt.v1.GdeltService.SearchThemes:input_type -> gdelt.v1.SearchThemesRequest
+	7,  // 17: gdelt.v1.GdeltService.ExportTimeline:input_type -> gdelt.v1.ExportTimelineRequest
+	10, // 18: gdelt.v1.GdeltService.CompareTimelines:input_type -> gdelt.v1.CompareTimelinesRequest
+	3,  // 19: gdelt.v1.GdeltService.SearchArticles:output_type -> gdelt.v1.SearchArticlesResponse
+	6,  // 20: gdelt.v1.GdeltService.GetTimeline:output_type -> gdelt.v1.GetTimelineResponse
+	15, // 21: gdelt.v1.GdeltService.GetWordCloud:output_type -> gdelt.v1.GetWordCloudResponse
+	18, // 22: gdelt.v1.GdeltService.SearchThemes:output_type -> gdelt.v1.SearchThemesResponse
+	8,  // 23: gdelt.v1.GdeltService.ExportTimeline:output_type -> gdelt.v1.ExportTimelineResponse
+	12, // 24: gdelt.v1.GdeltService.CompareTimelines:output_type -> gdelt.v1.CompareTimelinesResponse
+	19, // [19:25] is the sub-list for method output_type
+	13, // [13:19] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_gdelt_v1_gdelt_proto_init() }
@@ -472,7 +1476,7 @@ func file_gdelt_v1_gdelt_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gdelt_v1_gdelt_proto_rawDesc), len(file_gdelt_v1_gdelt_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -39,12 +39,28 @@ const (
 	// GdeltServiceGetTimelineProcedure is the fully-qualified name of the GdeltService's GetTimeline
 	// RPC.
 	GdeltServiceGetTimelineProcedure = "/gdelt.v1.GdeltService/GetTimeline"
+	// GdeltServiceGetWordCloudProcedure is the fully-qualified name of the GdeltService's GetWordCloud
+	// RPC.
+	GdeltServiceGetWordCloudProcedure = "/gdelt.v1.GdeltService/GetWordCloud"
+	// GdeltServiceSearchThemesProcedure is the fully-qualified name of the GdeltService's SearchThemes
+	// RPC.
+	GdeltServiceSearchThemesProcedure = "/gdelt.v1.GdeltService/SearchThemes"
+	// GdeltServiceExportTimelineProcedure is the fully-qualified name of the GdeltService's
+	// ExportTimeline RPC.
+	GdeltServiceExportTimelineProcedure = "/gdelt.v1.GdeltService/ExportTimeline"
+	// GdeltServiceCompareTimelinesProcedure is the fully-qualified name of the GdeltService's
+	// CompareTimelines RPC.
+	GdeltServiceCompareTimelinesProcedure = "/gdelt.v1.GdeltService/CompareTimelines"
 )
 
 // GdeltServiceClient is a client for the gdelt.v1.GdeltService service.
 type GdeltServiceClient interface {
 	SearchArticles(context.Context, *connect.Request[v1.SearchArticlesRequest]) (*connect.Response[v1.SearchArticlesResponse], error)
 	GetTimeline(context.Context, *connect.Request[v1.GetTimelineRequest]) (*connect.Response[v1.GetTimelineResponse], error)
+	GetWordCloud(context.Context, *connect.Request[v1.GetWordCloudRequest]) (*connect.Response[v1.GetWordCloudResponse], error)
+	SearchThemes(context.Context, *connect.Request[v1.SearchThemesRequest]) (*connect.Response[v1.SearchThemesResponse], error)
+	ExportTimeline(context.Context, *connect.Request[v1.ExportTimelineRequest]) (*connect.Response[v1.ExportTimelineResponse], error)
+	CompareTimelines(context.Context, *connect.Request[v1.CompareTimelinesRequest]) (*connect.Response[v1.CompareTimelinesResponse], error)
 }
 
 // NewGdeltServiceClient constructs a client for the gdelt.v1.GdeltService service. By default, it
@@ -70,13 +86,41 @@ func NewGdeltServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(gdeltServiceMethods.ByName("GetTimeline")),
 			connect.WithClientOptions(opts...),
 		),
+		getWordCloud: connect.NewClient[v1.GetWordCloudRequest, v1.GetWordCloudResponse](
+			httpClient,
+			baseURL+GdeltServiceGetWordCloudProcedure,
+			connect.WithSchema(gdeltServiceMethods.ByName("GetWordCloud")),
+			connect.WithClientOptions(opts...),
+		),
+		searchThemes: connect.NewClient[v1.SearchThemesRequest, v1.SearchThemesResponse](
+			httpClient,
+			baseURL+GdeltServiceSearchThemesProcedure,
+			connect.WithSchema(gdeltServiceMethods.ByName("SearchThemes")),
+			connect.WithClientOptions(opts...),
+		),
+		exportTimeline: connect.NewClient[v1.ExportTimelineRequest, v1.ExportTimelineResponse](
+			httpClient,
+			baseURL+GdeltServiceExportTimelineProcedure,
+			connect.WithSchema(gdeltServiceMethods.ByName("ExportTimeline")),
+			connect.WithClientOptions(opts...),
+		),
+		compareTimelines: connect.NewClient[v1.CompareTimelinesRequest, v1.CompareTimelinesResponse](
+			httpClient,
+			baseURL+GdeltServiceCompareTimelinesProcedure,
+			connect.WithSchema(gdeltServiceMethods.ByName("CompareTimelines")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // gdeltServiceClient implements GdeltServiceClient.
 type gdeltServiceClient struct {
-	searchArticles *connect.Client[v1.SearchArticlesRequest, v1.SearchArticlesResponse]
-	getTimeline    *connect.Client[v1.GetTimelineRequest, v1.GetTimelineResponse]
+	searchArticles   *connect.Client[v1.SearchArticlesRequest, v1.SearchArticlesResponse]
+	getTimeline      *connect.Client[v1.GetTimelineRequest, v1.GetTimelineResponse]
+	getWordCloud     *connect.Client[v1.GetWordCloudRequest, v1.GetWordCloudResponse]
+	searchThemes     *connect.Client[v1.SearchThemesRequest, v1.SearchThemesResponse]
+	exportTimeline   *connect.Client[v1.ExportTimelineRequest, v1.ExportTimelineResponse]
+	compareTimelines *connect.Client[v1.CompareTimelinesRequest, v1.CompareTimelinesResponse]
 }
 
 // SearchArticles calls gdelt.v1.GdeltService.SearchArticles.
@@ -89,10 +133,34 @@ func (c *gdeltServiceClient) GetTimeline(ctx context.Context, req *connect.Reque
 	return c.getTimeline.CallUnary(ctx, req)
 }
 
+// GetWordCloud calls gdelt.v1.GdeltService.GetWordCloud.
+func (c *gdeltServiceClient) GetWordCloud(ctx context.Context, req *connect.Request[v1.GetWordCloudRequest]) (*connect.Response[v1.GetWordCloudResponse], error) {
+	return c.getWordCloud.CallUnary(ctx, req)
+}
+
+// SearchThemes calls gdelt.v1.GdeltService.SearchThemes.
+func (c *gdeltServiceClient) SearchThemes(ctx context.Context, req *connect.Request[v1.SearchThemesRequest]) (*connect.Response[v1.SearchThemesResponse], error) {
+	return c.searchThemes.CallUnary(ctx, req)
+}
+
+// ExportTimeline calls gdelt.v1.GdeltService.ExportTimeline.
+func (c *gdeltServiceClient) ExportTimeline(ctx context.Context, req *connect.Request[v1.ExportTimelineRequest]) (*connect.Response[v1.ExportTimelineResponse], error) {
+	return c.exportTimeline.CallUnary(ctx, req)
+}
+
+// CompareTimelines calls gdelt.v1.GdeltService.CompareTimelines.
+func (c *gdeltServiceClient) CompareTimelines(ctx context.Context, req *connect.Request[v1.CompareTimelinesRequest]) (*connect.Response[v1.CompareTimelinesResponse], error) {
+	return c.compareTimelines.CallUnary(ctx, req)
+}
+
 // GdeltServiceHandler is an implementation of the gdelt.v1.GdeltService service.
 type GdeltServiceHandler interface {
 	SearchArticles(context.Context, *connect.Request[v1.SearchArticlesRequest]) (*connect.Response[v1.SearchArticlesResponse], error)
 	GetTimeline(context.Context, *connect.Request[v1.GetTimelineRequest]) (*connect.Response[v1.GetTimelineResponse], error)
+	GetWordCloud(context.Context, *connect.Request[v1.GetWordCloudRequest]) (*connect.Response[v1.GetWordCloudResponse], error)
+	SearchThemes(context.Context, *connect.Request[v1.SearchThemesRequest]) (*connect.Response[v1.SearchThemesResponse], error)
+	ExportTimeline(context.Context, *connect.Request[v1.ExportTimelineRequest]) (*connect.Response[v1.ExportTimelineResponse], error)
+	CompareTimelines(context.Context, *connect.Request[v1.CompareTimelinesRequest]) (*connect.Response[v1.CompareTimelinesResponse], error)
 }
 
 // NewGdeltServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -114,12 +182,44 @@ func NewGdeltServiceHandler(svc GdeltServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(gdeltServiceMethods.ByName("GetTimeline")),
 		connect.WithHandlerOptions(opts...),
 	)
+	gdeltServiceGetWordCloudHandler := connect.NewUnaryHandler(
+		GdeltServiceGetWordCloudProcedure,
+		svc.GetWordCloud,
+		connect.WithSchema(gdeltServiceMethods.ByName("GetWordCloud")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gdeltServiceSearchThemesHandler := connect.NewUnaryHandler(
+		GdeltServiceSearchThemesProcedure,
+		svc.SearchThemes,
+		connect.WithSchema(gdeltServiceMethods.ByName("SearchThemes")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gdeltServiceExportTimelineHandler := connect.NewUnaryHandler(
+		GdeltServiceExportTimelineProcedure,
+		svc.ExportTimeline,
+		connect.WithSchema(gdeltServiceMethods.ByName("ExportTimeline")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gdeltServiceCompareTimelinesHandler := connect.NewUnaryHandler(
+		GdeltServiceCompareTimelinesProcedure,
+		svc.CompareTimelines,
+		connect.WithSchema(gdeltServiceMethods.ByName("CompareTimelines")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/gdelt.v1.GdeltService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case GdeltServiceSearchArticlesProcedure:
 			gdeltServiceSearchArticlesHandler.ServeHTTP(w, r)
 		case GdeltServiceGetTimelineProcedure:
 			gdeltServiceGetTimelineHandler.ServeHTTP(w, r)
+		case GdeltServiceGetWordCloudProcedure:
+			gdeltServiceGetWordCloudHandler.ServeHTTP(w, r)
+		case GdeltServiceSearchThemesProcedure:
+			gdeltServiceSearchThemesHandler.ServeHTTP(w, r)
+		case GdeltServiceExportTimelineProcedure:
+			gdeltServiceExportTimelineHandler.ServeHTTP(w, r)
+		case GdeltServiceCompareTimelinesProcedure:
+			gdeltServiceCompareTimelinesHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -136,3 +236,19 @@ func (UnimplementedGdeltServiceHandler) SearchArticles(context.Context, *connect
 func (UnimplementedGdeltServiceHandler) GetTimeline(context.Context, *connect.Request[v1.GetTimelineRequest]) (*connect.Response[v1.GetTimelineResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gdelt.v1.GdeltService.GetTimeline is not implemented"))
 }
+
+func (UnimplementedGdeltServiceHandler) GetWordCloud(context.Context, *connect.Request[v1.GetWordCloudRequest]) (*connect.Response[v1.GetWordCloudResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gdelt.v1.GdeltService.GetWordCloud is not implemented"))
+}
+
+func (UnimplementedGdeltServiceHandler) SearchThemes(context.Context, *connect.Request[v1.SearchThemesRequest]) (*connect.Response[v1.SearchThemesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gdelt.v1.GdeltService.SearchThemes is not implemented"))
+}
+
+func (UnimplementedGdeltServiceHandler) ExportTimeline(context.Context, *connect.Request[v1.ExportTimelineRequest]) (*connect.Response[v1.ExportTimelineResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gdelt.v1.GdeltService.ExportTimeline is not implemented"))
+}
+
+func (UnimplementedGdeltServiceHandler) CompareTimelines(context.Context, *connect.Request[v1.CompareTimelinesRequest]) (*connect.Response[v1.CompareTimelinesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gdelt.v1.GdeltService.CompareTimelines is not implemented"))
+}